@@ -0,0 +1,15 @@
+module github.com/asaskevich/govalidator/v12/cmd
+
+go 1.25.0
+
+require (
+	github.com/asaskevich/govalidator/v12 v12.0.0-00010101000000-000000000000
+	golang.org/x/tools v0.46.0
+)
+
+require (
+	golang.org/x/mod v0.37.0 // indirect
+	golang.org/x/sync v0.21.0 // indirect
+)
+
+replace github.com/asaskevich/govalidator/v12 => ../
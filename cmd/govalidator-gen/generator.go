@@ -0,0 +1,770 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/asaskevich/govalidator/v12"
+)
+
+var govalidatorPath = reflect.TypeOf(govalidator.Error{}).PkgPath()
+
+// generator writes the Validate methods of struct types of a package.
+type generator struct {
+	pkg *types.Package
+	// targets are the types Validate is generated for
+	targets map[*types.TypeName]bool
+	// imports maps the paths of the packages used by the generated code to their names
+	imports map[string]string
+	buf     bytes.Buffer
+	// pos is the field being generated, e.g. "User.Email", for error messages
+	pos  string
+	errs []string
+}
+
+// generate returns the source of the Validate methods of the struct types named typeNames in pkg.
+// The options that can't be generated are reported together.
+func generate(pkg *types.Package, typeNames []string) ([]byte, error) {
+	g := &generator{
+		pkg:     pkg,
+		targets: map[*types.TypeName]bool{},
+		imports: map[string]string{govalidatorPath: "govalidator"},
+	}
+	var named []*types.Named
+	for _, name := range typeNames {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in package %s", name, pkg.Path())
+		}
+		t, ok := types.Unalias(obj.Type()).(*types.Named)
+		if !ok || t.Obj() != obj || !isStruct(t) {
+			return nil, fmt.Errorf("%s is not a struct type", name)
+		}
+		if t.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("%s: generic types are not supported", name)
+		}
+		g.targets[obj] = true
+		named = append(named, t)
+	}
+	for _, t := range named {
+		g.genType(t)
+	}
+	if len(g.errs) > 0 {
+		return nil, errors.New(strings.Join(g.errs, "\n"))
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by govalidator-gen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name())
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	// the standard packages come first
+	sort.SliceStable(paths, func(i, j int) bool {
+		return !strings.Contains(paths[i], ".") && strings.Contains(paths[j], ".")
+	})
+	for i, path := range paths {
+		if i > 0 && strings.Contains(path, ".") && !strings.Contains(paths[i-1], ".") {
+			fmt.Fprintf(&out, "\n")
+		}
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+	fmt.Fprintf(&out, ")\n")
+	out.Write(g.buf.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), fmt.Errorf("formatting the generated code: %v", err)
+	}
+	return src, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) errorf(format string, args ...interface{}) {
+	g.errs = append(g.errs, g.pos+": "+fmt.Sprintf(format, args...))
+}
+
+// use records the import of a standard package by the generated code.
+func (g *generator) use(path string) {
+	g.imports[path] = path
+}
+
+// qualifier names the packages of the types written in the generated code, importing them.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func (g *generator) genType(t *types.Named) {
+	name := t.Obj().Name()
+	g.pos = name
+	methods := types.NewMethodSet(types.NewPointer(t))
+	for _, hook := range []string{"ValidateStructLevel", "ValidateStructLevelCtx"} {
+		if methods.Lookup(nil, hook) != nil {
+			g.errorf("ValidateStruct calls the %s method, which a generated Validate would not", hook)
+			return
+		}
+	}
+	if methods.Lookup(nil, "Validate") != nil && methods.Lookup(nil, "GeneratedByGovalidator") == nil {
+		g.errorf("the type already has a Validate method")
+		return
+	}
+	g.printf("\n// Validate checks the valid tags of %s like govalidator.ValidateStruct, without reflection.\n", name)
+	g.printf("func (x *%s) Validate() error {\n", name)
+	g.printf("var errs govalidator.Errors\n")
+	g.genFields(name, "x", t.Underlying().(*types.Struct))
+	g.printf("if len(errs) > 0 {\nreturn errs\n}\nreturn nil\n}\n")
+	g.printf("\n// GeneratedByGovalidator tells govalidator-gen that Validate is generated, for the types containing %s.\n", name)
+	g.printf("func (*%s) GeneratedByGovalidator() {}\n", name)
+}
+
+// field is a struct field validated by the generated code.
+type field struct {
+	expr string
+	typ  types.Type
+	// name is the name of the field in errors: its JSON name when it has one
+	name   string
+	goName string
+	// embedded is set for an embedded struct having options, which apply to the struct as a whole
+	embedded bool
+}
+
+// genFields generates the validation of the fields of st, accessed through expr. Like
+// ValidateStruct, the fields of embedded structs without a JSON name are validated as fields of
+// the outer struct.
+func (g *generator) genFields(typeName, expr string, st *types.Struct) {
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("valid")
+		jsonName := toJSONName(reflect.StructTag(st.Tag(i)).Get("json"))
+		g.pos = typeName + "." + v.Name()
+		f := field{expr: expr + "." + v.Name(), typ: v.Type(), name: v.Name(), goName: v.Name()}
+		if jsonName != "" {
+			f.name = jsonName
+		}
+		if v.Embedded() && tag != "-" && jsonName == "" {
+			if p, ok := types.Unalias(v.Type()).(*types.Pointer); ok && isStruct(p.Elem()) {
+				g.errorf("embedded pointers to structs are not supported")
+				continue
+			}
+			if inner, ok := v.Type().Underlying().(*types.Struct); ok {
+				g.genFields(typeName, f.expr, inner)
+				if tag == "" {
+					continue
+				}
+				g.pos = typeName + "." + v.Name()
+				f.embedded = true
+			}
+		}
+		if !v.Exported() || tag == "-" {
+			continue
+		}
+		opts, err := g.parseOptions(tag)
+		if err != nil {
+			g.errorf("%v", err)
+			continue
+		}
+		g.genField(f, opts)
+	}
+}
+
+// fieldOptions are the options of a `valid` tag.
+type fieldOptions struct {
+	required        bool
+	requiredMessage string
+	redact          bool
+	validators      []validatorCall
+}
+
+func (o *fieldOptions) empty() bool {
+	return !o.required && len(o.validators) == 0
+}
+
+// validatorCall is a validator option along with the function applying it.
+type validatorCall struct {
+	spec    string
+	message string
+	negate  bool
+	// code is the Error.Code reported when the validator fails, e.g. "not_numeric"
+	code string
+	// fn is the Go expression of the validator function, e.g. govalidator.IsEmail
+	fn     string
+	params []string
+}
+
+// cond returns the condition under which the string s fails the validator.
+func (c *validatorCall) cond(s string) string {
+	args := []string{s}
+	for _, p := range c.params {
+		args = append(args, strconv.Quote(p))
+	}
+	call := c.fn + "(" + strings.Join(args, ", ") + ")"
+	if c.negate {
+		return call
+	}
+	return "!" + call
+}
+
+// paramsLiteral returns the Go expression of the params reported in Error.Params.
+func (c *validatorCall) paramsLiteral() string {
+	if c.params == nil {
+		return "nil"
+	}
+	params := make([]string, len(c.params))
+	for i, p := range c.params {
+		params[i] = strconv.Quote(p)
+	}
+	return "[]string{" + strings.Join(params, ", ") + "}"
+}
+
+// parseOptions parses tag with govalidator.ParseTag, rejecting the options whose result depends
+// on the settings or the registries of the validator at validation time.
+func (g *generator) parseOptions(tag string) (*fieldOptions, error) {
+	options, err := govalidator.ParseTag(tag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag: %v", err)
+	}
+	opts := &fieldOptions{}
+	for _, opt := range options {
+		switch {
+		case len(opt.Groups) > 0:
+			return nil, fmt.Errorf("%s: validation groups are not supported", opt.Spec())
+		case opt.Name == "dive" || opt.Name == "keys" || opt.Name == "endkeys":
+			return nil, fmt.Errorf("%s is not supported", opt.Name)
+		}
+		switch opt.Spec() {
+		case "required":
+			opts.required, opts.requiredMessage = true, opt.Message
+		case "optional":
+		case "redact":
+			opts.redact = true
+		default:
+			call, err := validatorFunc(opt)
+			if err != nil {
+				return nil, err
+			}
+			opts.validators = append(opts.validators, call)
+		}
+	}
+	return opts, nil
+}
+
+// validatorFunc resolves a validator option like ValidateStruct, against the validators of
+// ParamTagMap and TagMap.
+func validatorFunc(opt govalidator.TagOption) (validatorCall, error) {
+	call := validatorCall{spec: opt.Spec(), message: opt.Message, negate: opt.Negated, code: opt.Name}
+	if opt.Negated {
+		call.code = "not_" + opt.Name
+	}
+	validator := strings.TrimPrefix(call.spec, "!")
+	if _, _, ok := matchParamTag(validator, govalidator.InterfaceParamTagRegexMap, func(key string) bool {
+		_, ok := govalidator.InterfaceParamTagMap[key]
+		return ok
+	}); ok {
+		return call, fmt.Errorf("%s validates values of any kind, which is not supported", opt.Name)
+	}
+	if key, params, ok := matchParamTag(validator, govalidator.ParamTagRegexMap, func(key string) bool {
+		_, ok := govalidator.ParamTagMap[key]
+		return ok
+	}); ok {
+		call.fn, call.params = funcName(govalidator.ParamTagMap[key]), params
+		if call.fn == "" {
+			call.fn = fmt.Sprintf("govalidator.ParamTagMap[%q]", key)
+		}
+		return call, nil
+	}
+	if fn, ok := govalidator.TagMap[validator]; ok {
+		call.fn = funcName(fn)
+		if call.fn == "" {
+			call.fn = fmt.Sprintf("govalidator.TagMap[%q]", validator)
+		}
+		return call, nil
+	}
+	return call, fmt.Errorf("%s is not supported, only the validators of TagMap and ParamTagMap are", opt.Name)
+}
+
+// matchParamTag finds the param validator whose regex matches the option, trying the keys in
+// sorted order like ValidateStruct.
+func matchParamTag(validator string, regexMap map[string]*regexp.Regexp, registered func(string) bool) (string, []string, bool) {
+	keys := make([]string, 0, len(regexMap))
+	for key := range regexMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		ps := regexMap[key].FindStringSubmatch(validator)
+		if len(ps) == 0 || !registered(key) {
+			continue
+		}
+		return key, ps[1:], true
+	}
+	return "", nil, false
+}
+
+// funcName returns the qualified name of fn when it is an exported function of govalidator,
+// e.g. "govalidator.IsEmail", or "" otherwise.
+func funcName(fn interface{}) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	id := strings.TrimPrefix(name, govalidatorPath+".")
+	if id == name || !token.IsIdentifier(id) || !token.IsExported(id) {
+		return ""
+	}
+	return "govalidator." + id
+}
+
+// value is a value validated by the generated code: a field or an element of a collection.
+type value struct {
+	expr string
+	typ  types.Type
+	// name and field are the Go expressions of the name and the Go name reported in errors,
+	// e.g. "tags." + strconv.Itoa(i)
+	name  string
+	field string
+}
+
+// sinks are the statements collecting an error, the errors of collection elements being
+// checked after each element so that validation stops at the first failing one.
+const (
+	appendSink  = "errs = append(errs, %s)\n"
+	elementSink = "err = %s\n"
+)
+
+func (g *generator) genField(f field, opts *fieldOptions) {
+	v := value{expr: f.expr, typ: f.typ, name: strconv.Quote(f.name), field: strconv.Quote(f.goName)}
+	if f.embedded {
+		g.genStructOptions(v, opts)
+		return
+	}
+	switch u := f.typ.Underlying().(type) {
+	case *types.Basic:
+		g.genBasic(v, opts, appendSink)
+	case *types.Pointer:
+		if isStruct(u.Elem()) {
+			g.genNested(f, fmt.Sprintf("if %s != nil {\n", f.expr), f.expr, u.Elem(), opts)
+			g.genPointerOptions(v, opts, appendSink)
+			return
+		}
+		g.genValue(v, opts, appendSink)
+	case *types.Struct:
+		g.genNested(f, "", f.expr, f.typ, opts)
+		g.genStructOptions(v, opts)
+	case *types.Slice:
+		g.genSlice(f, v, u.Elem(), opts)
+	case *types.Map:
+		g.genMap(f, v, u, opts)
+	case *types.Interface:
+		g.errorf("interface fields are only validated by ValidateStruct, tag the field with valid:\"-\"")
+	default:
+		if !opts.empty() {
+			g.errorf("fields of type %s are not supported", f.typ)
+		}
+	}
+}
+
+// genNested generates the validation of the nested struct of type t at expr, its errors being
+// prefixed with the name of the field f. open is the statement enclosing it, if any.
+func (g *generator) genNested(f field, open, expr string, t types.Type, opts *fieldOptions) {
+	call := g.nestedCall(expr, t)
+	if call == "" {
+		return
+	}
+	if opts.redact {
+		g.errorf("redact is not supported on struct fields")
+		return
+	}
+	g.printf("%sif %s; err != nil {\n", open, call)
+	g.printf(appendSink, fmt.Sprintf("govalidator.PrependPathToErrors(err, %q)", f.name))
+	g.printf("}\n")
+	if open != "" {
+		g.printf("}\n")
+	}
+}
+
+// nestedCall returns the statement validating the struct of type t at expr, declaring err, or ""
+// when the struct has nothing to validate.
+func (g *generator) nestedCall(expr string, t types.Type) string {
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		methods := types.NewMethodSet(types.NewPointer(named))
+		if g.targets[named.Obj()] || methods.Lookup(nil, "GeneratedByGovalidator") != nil {
+			return "err := " + expr + ".Validate()"
+		}
+		if !hasFields(t.Underlying().(*types.Struct)) &&
+			methods.Lookup(nil, "ValidateStructLevel") == nil && methods.Lookup(nil, "ValidateStructLevelCtx") == nil {
+			return ""
+		}
+	}
+	return "_, err := govalidator.ValidateStruct(" + expr + ")"
+}
+
+// genStructOptions generates the check of the options of a struct field, which can only be required.
+func (g *generator) genStructOptions(v value, opts *fieldOptions) {
+	if len(opts.validators) > 0 {
+		g.errorf("validator %s doesn't support kind struct", opts.validators[0].spec)
+		return
+	}
+	if !opts.required {
+		return
+	}
+	if !types.Comparable(v.typ) {
+		g.errorf("required is not supported on structs that are not comparable")
+		return
+	}
+	g.printf("if %s == (%s{}) {\n", v.expr, types.TypeString(v.typ, g.qualifier))
+	g.printf(appendSink, g.requiredError(v, opts))
+	g.printf("}\n")
+}
+
+// genPointerOptions generates the check of the options of a pointer to a struct, which can only be required.
+func (g *generator) genPointerOptions(v value, opts *fieldOptions, sink string) {
+	if len(opts.validators) > 0 {
+		g.errorf("validator %s doesn't support kind struct", opts.validators[0].spec)
+		return
+	}
+	if opts.required {
+		g.printf("if %s == nil {\n", v.expr)
+		g.printf(sink, g.requiredError(v, opts))
+		g.printf("}\n")
+	}
+}
+
+// genValue generates the validation of a value of basic kind or a pointer to one.
+func (g *generator) genValue(v value, opts *fieldOptions, sink string) {
+	p, ok := v.typ.Underlying().(*types.Pointer)
+	if !ok {
+		g.genBasic(v, opts, sink)
+		return
+	}
+	if _, ok := p.Elem().Underlying().(*types.Basic); !ok {
+		if !opts.empty() {
+			g.errorf("values of type %s are not supported", v.typ)
+		}
+		return
+	}
+	elem := value{expr: "*" + v.expr, typ: p.Elem(), name: v.name, field: v.field}
+	switch {
+	case opts.required:
+		g.printf("if %s == nil {\n", v.expr)
+		g.printf(sink, g.requiredError(v, opts))
+		g.printf("} else {\n")
+		g.genBasic(elem, opts, sink)
+		g.printf("}\n")
+	case len(opts.validators) > 0:
+		g.printf("if %s != nil {\n", v.expr)
+		g.genBasic(elem, opts, sink)
+		g.printf("}\n")
+	}
+}
+
+// genBasic generates the validation of a value of basic kind: the required check of empty values,
+// then the validators applied to the value formatted like fmt.Sprint.
+func (g *generator) genBasic(v value, opts *fieldOptions, sink string) {
+	b, ok := v.typ.Underlying().(*types.Basic)
+	if !ok {
+		if !opts.empty() {
+			g.errorf("values of type %s are not supported", v.typ)
+		}
+		return
+	}
+	var empty string
+	switch {
+	case b.Info()&types.IsString != 0:
+		empty = v.expr + ` == ""`
+	case b.Info()&types.IsBoolean != 0:
+		empty = "!" + v.expr
+	case b.Info()&types.IsNumeric != 0:
+		empty = v.expr + " == 0"
+	default:
+		if !opts.empty() {
+			g.errorf("values of type %s are not supported", v.typ)
+		}
+		return
+	}
+	if len(opts.validators) == 0 {
+		if opts.required {
+			g.printf("if %s {\n", empty)
+			g.printf(sink, g.requiredError(v, opts))
+			g.printf("}\n")
+		}
+		return
+	}
+	str, ok := g.stringExpr(v.expr, v.typ)
+	if !ok {
+		g.errorf("validator %s doesn't support kind %s", opts.validators[0].spec, b)
+		return
+	}
+	if str == v.expr {
+		// the empty check reads better on s
+		empty = strings.Replace(empty, v.expr, "s", 1)
+	}
+	g.printf("switch s := %s; {\n", str)
+	g.printf("case %s:\n", empty)
+	if opts.required {
+		g.printf(sink, g.requiredError(v, opts))
+	}
+	for i := range opts.validators {
+		call := &opts.validators[i]
+		g.printf("case %s:\n", call.cond("s"))
+		g.printf(sink, fmt.Sprintf("govalidator.FieldError(%s, %s, %q, %q, %s, %q, %s, %s)",
+			v.name, v.field, call.spec, call.code, call.paramsLiteral(), call.message, messageArg(v, opts), valueArg(v, opts)))
+	}
+	g.printf("}\n")
+}
+
+// stringExpr returns the expression formatting the value at expr like fmt.Sprint, which
+// ValidateStruct passes to the validators. ok is false for the kinds it doesn't validate.
+func (g *generator) stringExpr(expr string, t types.Type) (string, bool) {
+	methods := types.NewMethodSet(t)
+	for _, name := range []string{"Format", "Error", "String"} {
+		if methods.Lookup(nil, name) != nil {
+			g.use("fmt")
+			return "fmt.Sprint(" + expr + ")", true
+		}
+	}
+	b := t.Underlying().(*types.Basic)
+	_, named := types.Unalias(t).(*types.Named)
+	info := b.Info()
+	switch {
+	case info&types.IsString != 0:
+		if named {
+			return "string(" + expr + ")", true
+		}
+		return expr, true
+	case b.Kind() == types.Uintptr || info&types.IsInteger == 0 && info&types.IsFloat == 0:
+		return "", false
+	}
+	g.use("strconv")
+	switch {
+	case b.Kind() == types.Int && !named:
+		return "strconv.Itoa(" + expr + ")", true
+	case info&types.IsUnsigned != 0:
+		return "strconv.FormatUint(uint64(" + expr + "), 10)", true
+	case info&types.IsInteger != 0:
+		return "strconv.FormatInt(int64(" + expr + "), 10)", true
+	case b.Kind() == types.Float32:
+		return "strconv.FormatFloat(float64(" + expr + "), 'g', -1, 32)", true
+	}
+	return "strconv.FormatFloat(float64(" + expr + "), 'g', -1, 64)", true
+}
+
+func (g *generator) requiredError(v value, opts *fieldOptions) string {
+	return fmt.Sprintf("govalidator.RequiredError(%s, %s, %q, %s)", v.name, v.field, opts.requiredMessage, valueArg(v, opts))
+}
+
+// valueArg returns the value reported in errors, nil when it is redacted.
+func valueArg(v value, opts *fieldOptions) string {
+	if opts.redact {
+		return "nil"
+	}
+	return v.expr
+}
+
+// messageArg returns the text standing for the value in messages, the name of the field
+// when the value is redacted.
+func messageArg(v value, opts *fieldOptions) string {
+	if opts.redact {
+		return v.name
+	}
+	return "s"
+}
+
+// genSlice generates the validation of a slice: the required check when it is empty, then the
+// validation of its elements, stopping at the first failing one.
+func (g *generator) genSlice(f field, v value, elem types.Type, opts *fieldOptions) {
+	if !g.genRequiredCollection(v, elem, opts) {
+		return
+	}
+	g.use("strconv")
+	if isStruct(elem) {
+		g.printf("for i := range %s {\n", v.expr)
+		g.genElementStruct(f, v.expr+"[i]", elem, "strconv.Itoa(i)")
+	} else {
+		g.printf("for i, v := range %s {\n", v.expr)
+		g.genElement(f, "v", elem, "strconv.Itoa(i)", opts)
+	}
+	g.printf("}\n")
+	if opts.required {
+		g.printf("}\n")
+	}
+}
+
+// genMap generates the validation of a map like genSlice, in the order of the sorted keys.
+func (g *generator) genMap(f field, v value, m *types.Map, opts *fieldOptions) {
+	if b, ok := m.Key().Underlying().(*types.Basic); !ok || b.Info()&types.IsString == 0 {
+		g.errorf("maps with keys of type %s are not supported", m.Key())
+		return
+	}
+	if !g.genRequiredCollection(v, m.Elem(), opts) {
+		return
+	}
+	if !opts.required {
+		g.printf("if len(%s) > 0 {\n", v.expr)
+	}
+	g.use("sort")
+	g.printf("keys := make([]string, 0, len(%s))\n", v.expr)
+	key := "k"
+	if _, named := types.Unalias(m.Key()).(*types.Named); named {
+		key = types.TypeString(m.Key(), g.qualifier) + "(k)"
+		g.printf("for k := range %s {\nkeys = append(keys, string(k))\n}\n", v.expr)
+	} else {
+		g.printf("for k := range %s {\nkeys = append(keys, k)\n}\n", v.expr)
+	}
+	g.printf("sort.Strings(keys)\n")
+	g.printf("for _, k := range keys {\n")
+	g.printf("v := %s[%s]\n", v.expr, key)
+	if isStruct(m.Elem()) {
+		g.genElementStruct(f, "v", m.Elem(), "k")
+	} else {
+		g.genElement(f, "v", m.Elem(), "k", opts)
+	}
+	g.printf("}\n}\n")
+}
+
+// genRequiredCollection generates the required check of an empty collection, opening the else
+// branch validating its elements when they have something to validate, as reported by the result.
+func (g *generator) genRequiredCollection(v value, elem types.Type, opts *fieldOptions) bool {
+	errs := len(g.errs)
+	elements := g.checkElements(elem, opts)
+	if len(g.errs) > errs || !opts.required {
+		return elements
+	}
+	g.printf("if len(%s) == 0 {\n", v.expr)
+	g.printf(appendSink, g.requiredError(v, opts))
+	if !elements {
+		g.printf("}\n")
+		return false
+	}
+	g.printf("} else {\n")
+	return true
+}
+
+// checkElements reports the options that can't be applied to collection elements of type elem,
+// and tells whether the elements have something to validate.
+func (g *generator) checkElements(elem types.Type, opts *fieldOptions) bool {
+	switch u := elem.Underlying().(type) {
+	case *types.Basic:
+		return !opts.empty()
+	case *types.Pointer:
+		if isStruct(u.Elem()) {
+			if len(opts.validators) > 0 {
+				g.errorf("validator %s doesn't support kind struct", opts.validators[0].spec)
+				return false
+			}
+			return opts.required
+		}
+		if _, ok := u.Elem().Underlying().(*types.Basic); ok {
+			return !opts.empty()
+		}
+	case *types.Struct:
+		switch {
+		case len(opts.validators) > 0:
+			g.errorf("validator %s doesn't support kind struct", opts.validators[0].spec)
+			return false
+		case opts.redact:
+			g.errorf("redact is not supported on struct fields")
+			return false
+		}
+		return g.nestedCall("", elem) != ""
+	case *types.Interface:
+		if opts.empty() {
+			return false
+		}
+	default:
+		if opts.empty() && !needsReflection(elem) {
+			return false
+		}
+	}
+	g.errorf("elements of type %s are not supported", elem)
+	return false
+}
+
+// genElement generates the validation of the element at expr of the collection of the field f,
+// idx being the expression of its index or key. The errors keep the name of the field, the index
+// being added to their path.
+func (g *generator) genElement(f field, expr string, elem types.Type, idx string, opts *fieldOptions) {
+	v := value{
+		expr:  expr,
+		typ:   elem,
+		name:  strconv.Quote(f.name),
+		field: strconv.Quote(f.goName),
+	}
+	g.printf("var err error\n")
+	if p, ok := elem.Underlying().(*types.Pointer); ok && isStruct(p.Elem()) {
+		// like ValidateStruct, only structs stored by value are validated
+		g.genPointerOptions(v, opts, elementSink)
+	} else {
+		g.genValue(v, opts, elementSink)
+	}
+	g.printf("if err != nil {\n")
+	g.printf(appendSink, fmt.Sprintf("govalidator.ElementErrors(err, %q, %s)", f.name, idx))
+	g.printf("break\n}\n")
+}
+
+// genElementStruct generates the validation of the struct element at expr, like genElement.
+func (g *generator) genElementStruct(f field, expr string, elem types.Type, idx string) {
+	call := g.nestedCall(expr, elem)
+	if call == "" {
+		return
+	}
+	g.printf("if %s; err != nil {\n", call)
+	g.printf(appendSink, fmt.Sprintf("govalidator.PrependPathToErrors(err, %q, %s)", f.name, idx))
+	g.printf("break\n}\n")
+}
+
+func isStruct(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+// hasFields reports whether ValidateStruct validates fields of st, that is whether it has
+// exported fields or embedded structs.
+func hasFields(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Exported() || st.Field(i).Embedded() {
+			return true
+		}
+	}
+	return false
+}
+
+// needsReflection reports whether ValidateStruct validates something in the values of the
+// collection type t without tags: the structs it contains, or maps with keys that are not
+// strings, which it rejects.
+func needsReflection(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		return true
+	case *types.Slice:
+		return needsReflection(u.Elem())
+	case *types.Map:
+		if b, ok := u.Key().Underlying().(*types.Basic); !ok || b.Info()&types.IsString == 0 {
+			return true
+		}
+		return needsReflection(u.Elem())
+	}
+	return false
+}
+
+// toJSONName returns the name of a field in JSON from its json tag, like ValidateStruct.
+func toJSONName(tag string) string {
+	name := strings.SplitN(tag, ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
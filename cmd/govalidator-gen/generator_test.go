@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden file")
+
+func TestGolden(t *testing.T) {
+	pkg, err := loadPackage([]string{"./internal/golden"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(pkg.Types, []string{"Account", "Address", "Item"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("internal", "golden", "account_validate.go")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated code differs from %s, run go test -update to update it:\n%s", golden, got)
+	}
+}
+
+// typeCheck type-checks the source of a package importing only standard packages.
+func typeCheck(t *testing.T, src string) *types.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "a.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("a", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"F string `valid:\"emial\"`", "T.F: invalid tag: column 1: unknown validator emial"},
+		{"F []string `valid:\"dive,alpha\"`", "T.F: dive is not supported"},
+		{"F string `valid:\"alpha@create\"`", "T.F: alpha: validation groups are not supported"},
+		{"F string `valid:\"eqfield(G)\"`\nG string", "T.F: eqfield is not supported, only the validators of TagMap and ParamTagMap are"},
+		{"F []string `valid:\"minitems(1)\"`", "T.F: minitems validates values of any kind, which is not supported"},
+		{"F bool `valid:\"alpha\"`", "T.F: validator alpha doesn't support kind bool"},
+		{"F S `valid:\"email\"`", "T.F: validator email doesn't support kind struct"},
+		{"F S `valid:\"redact\"`", "T.F: redact is not supported on struct fields"},
+		{"F struct{ M map[string]int } `valid:\"required\"`", "T.F: required is not supported on structs that are not comparable"},
+		{"F interface{}", `T.F: interface fields are only validated by ValidateStruct, tag the field with valid:"-"`},
+		{"F map[int]string", "T.F: maps with keys of type int are not supported"},
+		{"F [][]string `valid:\"alpha\"`", "T.F: elements of type []string are not supported"},
+		{"*S", "T.S: embedded pointers to structs are not supported"},
+	}
+	for _, tt := range tests {
+		pkg := typeCheck(t, "package a\n\ntype S struct{ A string `valid:\"email\"` }\n\ntype T struct {\n"+tt.field+"\n}\n")
+		_, err := generate(pkg, []string{"T"})
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: generate() = %v, want %s", tt.field, err, tt.want)
+		}
+	}
+}
+
+func TestGenerateTypes(t *testing.T) {
+	pkg := typeCheck(t, `package a
+
+import "errors"
+
+type S struct{}
+
+func (*S) Validate() error { return errors.New("S") }
+
+type C struct{}
+
+func (C) ValidateStructLevelCtx() error { return nil }
+
+type I int
+`)
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"X", "type X not found in package a"},
+		{"I", "I is not a struct type"},
+		{"S", "S: the type already has a Validate method"},
+		{"C", "C: ValidateStruct calls the ValidateStructLevelCtx method, which a generated Validate would not"},
+	}
+	for _, tt := range tests {
+		_, err := generate(pkg, []string{tt.name})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: generate() = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+}
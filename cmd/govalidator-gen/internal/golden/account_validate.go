@@ -0,0 +1,245 @@
+// Code generated by govalidator-gen; DO NOT EDIT.
+
+package golden
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/asaskevich/govalidator/v12"
+)
+
+// Validate checks the valid tags of Account like govalidator.ValidateStruct, without reflection.
+func (x *Account) Validate() error {
+	var errs govalidator.Errors
+	switch s := x.Base.ID; {
+	case s == "":
+		errs = append(errs, govalidator.RequiredError("ID", "ID", "ID is missing", x.Base.ID))
+	case !govalidator.IsUUIDv4(s):
+		errs = append(errs, govalidator.FieldError("ID", "ID", "uuidv4", "uuidv4", nil, "", s, x.Base.ID))
+	}
+	switch s := x.Email; {
+	case s == "":
+		errs = append(errs, govalidator.RequiredError("email", "Email", "", x.Email))
+	case !govalidator.IsEmail(s):
+		errs = append(errs, govalidator.FieldError("email", "Email", "email", "email", nil, "", s, x.Email))
+	}
+	switch s := x.Name; {
+	case s == "":
+	case !govalidator.StringLength(s, "2", "20"):
+		errs = append(errs, govalidator.FieldError("Name", "Name", "stringlength(2|20)", "stringlength", []string{"2", "20"}, "Name must have 2 to 20 characters", s, x.Name))
+	case govalidator.IsNumeric(s):
+		errs = append(errs, govalidator.FieldError("Name", "Name", "!numeric", "not_numeric", nil, "", s, x.Name))
+	}
+	if x.Nick != nil {
+		switch s := *x.Nick; {
+		case s == "":
+		case !govalidator.IsAlphanumeric(s):
+			errs = append(errs, govalidator.FieldError("Nick", "Nick", "alphanum", "alphanum", nil, "", s, *x.Nick))
+		}
+	}
+	switch s := strconv.Itoa(x.Age); {
+	case x.Age == 0:
+	case !govalidator.Range(s, "18", "130"):
+		errs = append(errs, govalidator.FieldError("Age", "Age", "range(18|130)", "range", []string{"18", "130"}, "", s, x.Age))
+	}
+	switch s := strconv.FormatFloat(float64(x.Score), 'g', -1, 64); {
+	case x.Score == 0:
+	case !govalidator.Range(s, "0", "1"):
+		errs = append(errs, govalidator.FieldError("Score", "Score", "range(0|1)", "range", []string{"0", "1"}, "", s, x.Score))
+	}
+	switch s := strconv.FormatFloat(float64(x.Ratio), 'g', -1, 32); {
+	case x.Ratio == 0:
+	case !govalidator.Range(s, "0", "1"):
+		errs = append(errs, govalidator.FieldError("Ratio", "Ratio", "range(0|1)", "range", []string{"0", "1"}, "", s, x.Ratio))
+	}
+	if x.Count == 0 {
+		errs = append(errs, govalidator.RequiredError("Count", "Count", "", x.Count))
+	}
+	switch s := strconv.FormatInt(int64(x.Level), 10); {
+	case x.Level == 0:
+	case !govalidator.IsInRaw(s, "1|2|3"):
+		errs = append(errs, govalidator.FieldError("Level", "Level", "in(1|2|3)", "in", []string{"1|2|3"}, "", s, x.Level))
+	}
+	switch s := fmt.Sprint(x.Status); {
+	case x.Status == "":
+	case !govalidator.IsInRaw(s, "ACTIVE|LOCKED"):
+		errs = append(errs, govalidator.FieldError("Status", "Status", "in(ACTIVE|LOCKED)", "in", []string{"ACTIVE|LOCKED"}, "", s, x.Status))
+	}
+	switch s := x.Unit; {
+	case s == "":
+	case !govalidator.IsInRaw(s, "m,s|kg"):
+		errs = append(errs, govalidator.FieldError("Unit", "Unit", "in(m,s|kg)", "in", []string{"m,s|kg"}, "", s, x.Unit))
+	}
+	switch s := x.Mode; {
+	case s == "":
+	case !govalidator.StringMatches(s, "^r$|^w$"):
+		errs = append(errs, govalidator.FieldError("Mode", "Mode", "matches(^r$|^w$)", "matches", []string{"^r$|^w$"}, "", s, x.Mode))
+	}
+	if !x.Accepted {
+		errs = append(errs, govalidator.RequiredError("Accepted", "Accepted", "", x.Accepted))
+	}
+	switch s := x.Password; {
+	case s == "":
+		errs = append(errs, govalidator.RequiredError("Password", "Password", "", nil))
+	case !govalidator.ByteLength(s, "8", "64"):
+		errs = append(errs, govalidator.FieldError("Password", "Password", "length(8|64)", "length", []string{"8", "64"}, "", "Password", nil))
+	}
+	for i, v := range x.Tags {
+		var err error
+		switch s := v; {
+		case s == "":
+		case !govalidator.IsAlpha(s):
+			err = govalidator.FieldError("tags", "Tags", "alpha", "alpha", nil, "", s, v)
+		}
+		if err != nil {
+			errs = append(errs, govalidator.ElementErrors(err, "tags", strconv.Itoa(i)))
+			break
+		}
+	}
+	if len(x.Codes) == 0 {
+		errs = append(errs, govalidator.RequiredError("Codes", "Codes", "", x.Codes))
+	} else {
+		for i, v := range x.Codes {
+			var err error
+			if v == nil {
+				err = govalidator.RequiredError("Codes", "Codes", "", v)
+			} else {
+				switch s := *v; {
+				case s == "":
+					err = govalidator.RequiredError("Codes", "Codes", "", *v)
+				case !govalidator.IsNumeric(s):
+					err = govalidator.FieldError("Codes", "Codes", "numeric", "numeric", nil, "", s, *v)
+				}
+			}
+			if err != nil {
+				errs = append(errs, govalidator.ElementErrors(err, "Codes", strconv.Itoa(i)))
+				break
+			}
+		}
+	}
+	if len(x.Labels) == 0 {
+		errs = append(errs, govalidator.RequiredError("Labels", "Labels", "", x.Labels))
+	} else {
+		keys := make([]string, 0, len(x.Labels))
+		for k := range x.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := x.Labels[k]
+			var err error
+			switch s := v; {
+			case s == "":
+				err = govalidator.RequiredError("Labels", "Labels", "", v)
+			case !govalidator.IsAlphanumeric(s):
+				err = govalidator.FieldError("Labels", "Labels", "alphanum", "alphanum", nil, "", s, v)
+			}
+			if err != nil {
+				errs = append(errs, govalidator.ElementErrors(err, "Labels", k))
+				break
+			}
+		}
+	}
+	switch s := x.Pattern; {
+	case s == "":
+	case !govalidator.StringMatches(s, "^[a-z]+(,[a-z]+)*$"):
+		errs = append(errs, govalidator.FieldError("Pattern", "Pattern", "matches(^[a-z]+(,[a-z]+)*$)", "matches", []string{"^[a-z]+(,[a-z]+)*$"}, "", s, x.Pattern))
+	}
+	if err := x.Home.Validate(); err != nil {
+		errs = append(errs, govalidator.PrependPathToErrors(err, "home"))
+	}
+	if x.Home == (Address{}) {
+		errs = append(errs, govalidator.RequiredError("home", "Home", "", x.Home))
+	}
+	if x.Work != nil {
+		if err := x.Work.Validate(); err != nil {
+			errs = append(errs, govalidator.PrependPathToErrors(err, "Work"))
+		}
+	}
+	if x.Work == nil {
+		errs = append(errs, govalidator.RequiredError("Work", "Work", "", x.Work))
+	}
+	for i := range x.Items {
+		if err := x.Items[i].Validate(); err != nil {
+			errs = append(errs, govalidator.PrependPathToErrors(err, "items", strconv.Itoa(i)))
+			break
+		}
+	}
+	if len(x.Stock) > 0 {
+		keys := make([]string, 0, len(x.Stock))
+		for k := range x.Stock {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := x.Stock[k]
+			if err := v.Validate(); err != nil {
+				errs = append(errs, govalidator.PrependPathToErrors(err, "Stock", k))
+				break
+			}
+		}
+	}
+	if _, err := govalidator.ValidateStruct(x.Audit); err != nil {
+		errs = append(errs, govalidator.PrependPathToErrors(err, "Audit"))
+	}
+	if x.Created == (time.Time{}) {
+		errs = append(errs, govalidator.RequiredError("Created", "Created", "", x.Created))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// GeneratedByGovalidator tells govalidator-gen that Validate is generated, for the types containing Account.
+func (*Account) GeneratedByGovalidator() {}
+
+// Validate checks the valid tags of Address like govalidator.ValidateStruct, without reflection.
+func (x *Address) Validate() error {
+	var errs govalidator.Errors
+	if x.Street == "" {
+		errs = append(errs, govalidator.RequiredError("Street", "Street", "", x.Street))
+	}
+	switch s := x.Zip; {
+	case s == "":
+	case !govalidator.IsNumeric(s):
+		errs = append(errs, govalidator.FieldError("zip", "Zip", "numeric", "numeric", nil, "", s, x.Zip))
+	case !govalidator.ByteLength(s, "5", "5"):
+		errs = append(errs, govalidator.FieldError("zip", "Zip", "length(5|5)", "length", []string{"5", "5"}, "Zip: five digits, please", s, x.Zip))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// GeneratedByGovalidator tells govalidator-gen that Validate is generated, for the types containing Address.
+func (*Address) GeneratedByGovalidator() {}
+
+// Validate checks the valid tags of Item like govalidator.ValidateStruct, without reflection.
+func (x *Item) Validate() error {
+	var errs govalidator.Errors
+	switch s := x.SKU; {
+	case s == "":
+		errs = append(errs, govalidator.RequiredError("SKU", "SKU", "", x.SKU))
+	case !govalidator.IsAlphanumeric(s):
+		errs = append(errs, govalidator.FieldError("SKU", "SKU", "alphanum", "alphanum", nil, "", s, x.SKU))
+	}
+	if x.Price == nil {
+		errs = append(errs, govalidator.RequiredError("Price", "Price", "", x.Price))
+	} else {
+		if *x.Price == 0 {
+			errs = append(errs, govalidator.RequiredError("Price", "Price", "", *x.Price))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// GeneratedByGovalidator tells govalidator-gen that Validate is generated, for the types containing Item.
+func (*Item) GeneratedByGovalidator() {}
@@ -0,0 +1,111 @@
+package golden
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/asaskevich/govalidator/v12"
+)
+
+func validAccount() Account {
+	nick, code, price := "bob42", "123", 9.5
+	return Account{
+		Base:     Base{ID: "a3bb189e-8bf9-4888-9912-ace4e6543002"},
+		Email:    "bob@example.com",
+		Name:     "Bob",
+		Nick:     &nick,
+		Age:      30,
+		Score:    0.5,
+		Ratio:    0.25,
+		Count:    1,
+		Level:    2,
+		Status:   "active",
+		Unit:     "m,s",
+		Mode:     "w",
+		Accepted: true,
+		Password: "correct horse",
+		Tags:     []string{"a", "b"},
+		Codes:    []*string{&code},
+		Labels:   map[string]string{"env": "prod"},
+		Pattern:  "a,b",
+		Home:     Address{Street: "Main St", Zip: "12345"},
+		Work:     &Address{Street: "Side St"},
+		Items:    []Item{{SKU: "x1", Price: &price}},
+		Stock:    map[string]Item{"x1": {SKU: "x1", Price: &price}},
+		Audit:    Audit{By: "alice@example.com"},
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestValidateMatchesValidateStruct(t *testing.T) {
+	nick, code, empty, zero := "bob 42", "12a", "", 0.0
+	tests := []struct {
+		name   string
+		modify func(a *Account)
+		valid  bool
+	}{
+		{"valid", func(a *Account) {}, true},
+		{"zero", func(a *Account) { *a = Account{} }, false},
+		{"embedded field", func(a *Account) { a.ID = "not a uuid" }, false},
+		{"embedded required message", func(a *Account) { a.ID = "" }, false},
+		{"email", func(a *Account) { a.Email = "bob" }, false},
+		{"custom message", func(a *Account) { a.Name = "B" }, false},
+		{"negated", func(a *Account) { a.Name = "123" }, false},
+		{"optional empty", func(a *Account) { a.Name, a.Nick, a.Age = "", nil, 0 }, true},
+		{"pointer", func(a *Account) { a.Nick = &nick }, false},
+		{"empty pointer", func(a *Account) { a.Nick = &empty }, true},
+		{"int", func(a *Account) { a.Age = 7 }, false},
+		{"float64", func(a *Account) { a.Score = 1.5 }, false},
+		{"float64 exponent", func(a *Account) { a.Score = 1e-7 }, true},
+		{"float32", func(a *Account) { a.Ratio = 1.1 }, false},
+		{"named int", func(a *Account) { a.Level = 4 }, false},
+		{"Stringer", func(a *Account) { a.Status = "gone" }, false},
+		{"quoted param", func(a *Account) { a.Unit = "m" }, false},
+		{"alternatives", func(a *Account) { a.Mode = "x" }, false},
+		{"redacted", func(a *Account) { a.Password = "short" }, false},
+		{"slice element", func(a *Account) { a.Tags = []string{"a", "b1", "c2"} }, false},
+		{"empty slice element", func(a *Account) { a.Tags = []string{"a", ""} }, true},
+		{"required slice", func(a *Account) { a.Codes = nil }, false},
+		{"nil slice element", func(a *Account) { a.Codes = []*string{nil} }, false},
+		{"empty slice element pointer", func(a *Account) { a.Codes = []*string{&empty} }, false},
+		{"slice element pointer", func(a *Account) { a.Codes = []*string{&code} }, false},
+		{"map value", func(a *Account) { a.Labels = map[string]string{"b": "x y", "a": "", "c": "z z"} }, false},
+		{"required map", func(a *Account) { a.Labels = map[string]string{} }, false},
+		{"params with commas", func(a *Account) { a.Pattern = "a,,b" }, false},
+		{"nested", func(a *Account) { a.Home.Zip = "1234" }, false},
+		{"nested required", func(a *Account) { a.Home = Address{} }, false},
+		{"nested pointer", func(a *Account) { a.Work = &Address{Zip: "x"} }, false},
+		{"nil nested pointer", func(a *Account) { a.Work = nil }, false},
+		{"slice of structs", func(a *Account) {
+			a.Items = []Item{{SKU: "x1", Price: &zero}, {SKU: "-"}, {}}
+		}, false},
+		{"map of structs", func(a *Account) { a.Stock = map[string]Item{"b": {}, "a": {SKU: "x"}} }, false},
+		{"struct without generated Validate", func(a *Account) { a.Audit.By = "alice" }, false},
+		{"required struct", func(a *Account) { a.Created = time.Time{} }, false},
+		{"skipped field", func(a *Account) { a.Secret, a.internal = "-", "-" }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := validAccount()
+			tt.modify(&a)
+			err := a.Validate()
+			if (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+			_, want := govalidator.ValidateStruct(a)
+			if !reflect.DeepEqual(err, want) {
+				t.Errorf("Validate() = %#v\nValidateStruct() = %#v", err, want)
+			}
+		})
+	}
+}
+
+func TestValidateStructSkipsGeneratedValidate(t *testing.T) {
+	// the errors would be reported twice if ValidateStruct called Validate as a hook
+	a := Address{Zip: "x"}
+	_, err := govalidator.ValidateStruct(a)
+	if got := len(err.(govalidator.Errors)); got != 2 {
+		t.Errorf("ValidateStruct() = %v, want 2 errors", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Package golden holds the types whose generated Validate methods are the golden file of
+// govalidator-gen, and checks that they return the errors of govalidator.ValidateStruct.
+package golden
+
+import (
+	"strings"
+	"time"
+)
+
+//go:generate go run ../.. -type=Account,Address,Item
+
+// Status is a string type with a String method, which the validators are applied to.
+type Status string
+
+func (s Status) String() string {
+	return strings.ToUpper(string(s))
+}
+
+// Level is a named integer type.
+type Level int8
+
+// Audit is a nested struct without a generated Validate method.
+type Audit struct {
+	By string `valid:"email"`
+}
+
+// Base is embedded in Account, its fields being validated as fields of Account.
+type Base struct {
+	ID string `valid:"uuidv4,required~ID is missing"`
+}
+
+// Account covers the kinds of fields govalidator-gen supports.
+type Account struct {
+	Base
+	Email    string            `json:"email" valid:"required,email"`
+	Name     string            `valid:"stringlength(2|20)~Name must have 2 to 20 characters,!numeric"`
+	Nick     *string           `valid:"alphanum"`
+	Age      int               `valid:"range(18|130)"`
+	Score    float64           `valid:"range(0|1)"`
+	Ratio    float32           `valid:"range(0|1)"`
+	Count    uint16            `valid:"required"`
+	Level    Level             `valid:"in(1|2|3)"`
+	Status   Status            `valid:"in(ACTIVE|LOCKED)"`
+	Unit     string            `valid:"in('m,s'|kg)"`
+	Mode     string            `valid:"matches(^r$|^w$)"`
+	Accepted bool              `valid:"required"`
+	Password string            `valid:"required,redact,length(8|64)"`
+	Tags     []string          `json:"tags" valid:"alpha"`
+	Codes    []*string         `valid:"required,numeric"`
+	Labels   map[string]string `valid:"alphanum,required"`
+	Pattern  string            `valid:"matches(^[a-z]+(,[a-z]+)*$)"`
+	Home     Address           `json:"home" valid:"required"`
+	Work     *Address          `valid:"required"`
+	Items    []Item            `json:"items"`
+	Stock    map[string]Item
+	Audit    Audit
+	Created  time.Time `valid:"required"`
+	Secret   string    `valid:"-"`
+	internal string
+}
+
+// Address is validated through its generated Validate method when nested.
+type Address struct {
+	Street string `valid:"required"`
+	Zip    string `json:"zip" valid:"numeric,length(5|5)~'Zip: five digits, please'"`
+}
+
+// Item is validated as an element of slices and maps.
+type Item struct {
+	SKU   string   `valid:"required,alphanum"`
+	Price *float64 `valid:"required"`
+}
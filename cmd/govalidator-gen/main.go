@@ -0,0 +1,94 @@
+// Command govalidator-gen generates Validate methods checking the `valid` tags of struct types
+// without reflection:
+//
+//	//go:generate govalidator-gen -type=User,Address
+//
+// For every type T it writes a method func (x *T) Validate() error returning the errors
+// govalidator.ValidateStruct returns with the default settings, calling the validators such as
+// govalidator.IsEmail directly, and a GeneratedByGovalidator method marking it as generated. Nested
+// structs are validated by their generated Validate method if they have one, by
+// govalidator.ValidateStruct otherwise. Types with a ValidateStructLevel hook are not supported. The methods are written to
+// <type>_validate.go in the directory of the package, <type> being the first type in lower case,
+// unless -output is set.
+//
+// Options depending on what is configured or registered at validation time are reported instead
+// of being generated: dive, validation groups, cross-field and conditional validators, validators
+// of InterfaceParamTagMap and validators added at runtime. Interface fields must be tagged with
+// valid:"-".
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output    = flag.String("output", "", "output file name; default <dir>/<type>_validate.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of govalidator-gen:\n")
+	fmt.Fprintf(os.Stderr, "\tgovalidator-gen -type T [package]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("govalidator-gen: ")
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	pkg, err := loadPackage(patterns)
+	if err != nil {
+		log.Fatal(err)
+	}
+	names := strings.Split(*typeNames, ",")
+	src, err := generate(pkg.Types, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	outputName := *output
+	if outputName == "" {
+		outputName = filepath.Join(filepath.Dir(pkg.GoFiles[0]), strings.ToLower(names[0])+"_validate.go")
+	}
+	if err := os.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadPackage loads the type information of the single package matched by patterns. Type errors
+// are ignored, as they may come from a previously generated file that is out of date.
+func loadPackage(patterns []string) (*packages.Package, error) {
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%d packages found", len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.GoFiles) == 0 || pkg.Types == nil {
+		if len(pkg.Errors) > 0 {
+			return nil, pkg.Errors[0]
+		}
+		return nil, errors.New("no Go files found")
+	}
+	return pkg, nil
+}
@@ -0,0 +1,16 @@
+// Command govalidator-vet checks the `valid` struct tags of govalidator in Go packages:
+//
+//	govalidator-vet ./...
+//	govalidator-vet -validators='animal(),even' ./...
+//
+// It can be run by go vet too: go vet -vettool=$(which govalidator-vet) ./...
+package main
+
+import (
+	"github.com/asaskevich/govalidator/v12/cmd/validtag"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(validtag.Analyzer)
+}
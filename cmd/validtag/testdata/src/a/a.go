@@ -0,0 +1,17 @@
+package a
+
+type User struct {
+	Name    string `json:"name" valid:"required,emial"` // want `invalid valid tag: unknown validator emial`
+	Email   string `valid:"required,email~'Please, an email'"`
+	Code    string `valid:"matches(^[a-z]{1,3}\\d$)"`
+	Age     int    `valid:"range(18)"`       // want `invalid valid tag: malformed params for range`
+	Zip     string `valid:"numeric,numeric"` // want `invalid valid tag: duplicate option numeric, first at column 1`
+	Kind    string `valid:"in('a|b)"`        // want `invalid valid tag: unterminated quote`
+	Animal  string `valid:"animal(cat),even"`
+	Ignored string `valid:"-"`
+	Other   string `json:"other"`
+}
+
+var _ = struct {
+	Title string `valid:"alpha,lenght(1|10)"` // want `invalid valid tag: unknown validator lenght`
+}{}
@@ -0,0 +1,131 @@
+// Package validtag defines an Analyzer that checks the `valid` struct tags of govalidator
+// without running the code.
+//
+// Every option is parsed with govalidator.ParseTag and checked against the built-in validators
+// of TagMap, ParamTagMap and InterfaceParamTagMap, reporting unknown validators, params that
+// don't match the pattern of their validator, duplicate options and malformed quoting at the
+// position of the problem in the tag. Validators registered at runtime are declared with the
+// -validators flag.
+package validtag
+
+import (
+	"flag"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/asaskevich/govalidator/v12"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check the valid struct tags of govalidator
+
+Reports the options of valid tags that govalidator can't apply: unknown validators,
+malformed params, duplicate options, unterminated quotes and parentheses.`
+
+// Analyzer checks the `valid` struct tags.
+var Analyzer = &analysis.Analyzer{
+	Name:     "validtag",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+	Flags:    flags(),
+}
+
+var (
+	tagName    string
+	validators string
+)
+
+func flags() flag.FlagSet {
+	fs := flag.NewFlagSet("validtag", flag.ExitOnError)
+	fs.StringVar(&tagName, "tag", "valid", "struct tag key the rules are read from")
+	fs.StringVar(&validators, "validators", "",
+		"comma-separated names of the validators registered at runtime, with () for the ones taking params, e.g. animal(),even")
+	return *fs
+}
+
+// newValidator returns a StructValidator knowing the built-in validators and the ones declared
+// with the -validators flag, which accept any value.
+func newValidator() *govalidator.StructValidator {
+	sv := govalidator.New(govalidator.WithTagName(tagName))
+	for _, name := range strings.Split(validators, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+		case strings.HasSuffix(name, "()"):
+			name = strings.TrimSuffix(name, "()")
+			sv.AddParamValidator(name, regexp.MustCompile(`^`+regexp.QuoteMeta(name)+`\((.*)\)$`),
+				func(string, ...string) bool { return true })
+		default:
+			sv.AddValidator(name, func(string) bool { return true })
+		}
+	}
+	return sv
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	sv := newValidator()
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag != nil {
+				checkTag(pass, sv, field.Tag)
+			}
+		}
+	})
+	return nil, nil
+}
+
+// checkTag reports the problems of the tag literal lit.
+func checkTag(pass *analysis.Pass, sv *govalidator.StructValidator, lit *ast.BasicLit) {
+	tags, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return
+	}
+	tag, ok := reflect.StructTag(tags).Lookup(tagName)
+	if !ok {
+		return
+	}
+	_, err = sv.ParseTag(tag)
+	if err == nil {
+		return
+	}
+	for _, e := range err.(govalidator.Errors) {
+		if tagErr, ok := e.(*govalidator.TagError); ok {
+			pass.Reportf(position(lit, tagErr.Column), "invalid %s tag: %s", tagName, tagErr.Msg)
+		} else {
+			pass.Reportf(lit.Pos(), "invalid %s tag: %v", tagName, e)
+		}
+	}
+}
+
+// position returns the position of the character at column in the value of the tag key of lit.
+// It falls back to the position of lit when the literal uses escapes it can't map.
+func position(lit *ast.BasicLit, column int) token.Pos {
+	raw := lit.Value
+	if raw[0] != '`' {
+		return lit.Pos()
+	}
+	start := strings.Index(raw, tagName+`:"`)
+	if start < 0 {
+		return lit.Pos()
+	}
+	// the value is quoted again inside the tag, so escapes take two characters
+	offset := start + len(tagName) + 2
+	for i := 1; i < column && offset < len(raw); i++ {
+		if raw[offset] == '\\' {
+			if next := raw[offset+1]; next != '\\' && next != '"' {
+				return lit.Pos()
+			}
+			offset++
+		}
+		offset++
+	}
+	return lit.Pos() + token.Pos(offset)
+}
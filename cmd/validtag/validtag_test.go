@@ -0,0 +1,41 @@
+package validtag
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	if err := Analyzer.Flags.Set("validators", "animal(),even"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestPosition(t *testing.T) {
+	var tests = []struct {
+		lit      string
+		column   int
+		expected int
+	}{
+		{"`valid:\"required,emial\"`", 10, 17},
+		{"`json:\"name\" valid:\"required,emial\"`", 10, 29},
+		{"`valid:\"matches(^\\\\d$),emial\"`", 15, 23},
+		{"`valid:\"email\\tfoo\"`", 7, 0},
+		{"\"valid:\\\"required,emial\\\"\"", 10, 0},
+	}
+	for _, test := range tests {
+		expr, err := parser.ParseExpr(test.lit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lit := expr.(*ast.BasicLit)
+		if actual := position(lit, test.column) - lit.Pos(); actual != token.Pos(test.expected) {
+			t.Errorf("Expected position(%s, %d) to be at offset %d, got %d", test.lit, test.column, test.expected, actual)
+		}
+	}
+}
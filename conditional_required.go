@@ -0,0 +1,86 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// conditionalRequiredTagRegexp matches the tags making a field required depending on other fields,
+// e.g. `required_if(Country|DE|FR)` or `required_without(Phone)`.
+var conditionalRequiredTagRegexp = regexp.MustCompile(`^(required_(?:if|unless|with|without))\((.+)\)$`)
+
+// compileConditionalRequired fills vp for a conditional requirement tag.
+// Malformed tags are left unresolved so they are reported as invalid validators.
+func compileConditionalRequired(vp *validatorPlan, ps []string) {
+	params := strings.Split(ps[2], "|")
+	if (ps[1] == "required_if" || ps[1] == "required_unless") && len(params) < 2 {
+		// a field and at least one value are needed
+		return
+	}
+	vp.kind, vp.key, vp.params = conditionalRequiredValidator, ps[1], params
+}
+
+// isConditionMet reports whether the conditional requirement vp applies, resolving the
+// referenced fields from the parent struct or map o.
+func isConditionMet(vp *validatorPlan, o reflect.Value) bool {
+	switch vp.key {
+	case "required_if", "required_unless":
+		equal := false
+		if other, ok := resolveFieldPath(o, vp.params[0]); ok {
+			if other = indirectValue(other); other.IsValid() {
+				equal = IsIn(fmt.Sprint(other), vp.params[1:]...)
+			}
+		}
+		return equal == (vp.key == "required_if")
+	case "required_with":
+		for _, path := range vp.params {
+			if isFieldPresent(o, path) {
+				return true
+			}
+		}
+	case "required_without":
+		for _, path := range vp.params {
+			if !isFieldPresent(o, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isFieldPresent(o reflect.Value, path string) bool {
+	other, ok := resolveFieldPath(o, path)
+	return ok && !isEmptyValue(other)
+}
+
+// conditionalRequirement returns the first conditional requirement of f that applies.
+func conditionalRequirement(f *tagPlan, o reflect.Value) (*validatorPlan, bool) {
+	for i := range f.validators {
+		vp := &f.validators[i]
+		if vp.kind == conditionalRequiredValidator && isConditionMet(vp, o) {
+			return vp, true
+		}
+	}
+	return nil, false
+}
+
+func hasConditionalRequirement(f *tagPlan) bool {
+	for i := range f.validators {
+		if f.validators[i].kind == conditionalRequiredValidator {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionalRequiredError builds the error for an empty field whose conditional requirement applies.
+func conditionalRequiredError(name string, vp *validatorPlan, message string) error {
+	err := Error{Name: name, Err: fmt.Errorf("%s by %s", message, vp.validator), Validator: vp.key, Path: []string{},
+		Field: name, Code: vp.key, Params: vp.errorParams()}
+	if len(vp.customErrorMessage) > 0 {
+		err.Err, err.CustomErrorMessageExists = fmt.Errorf(vp.customErrorMessage), true
+	}
+	return err
+}
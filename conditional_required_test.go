@@ -0,0 +1,108 @@
+package govalidator
+
+import "testing"
+
+type conditionalRequiredStruct struct {
+	Country   string `valid:"required"`
+	VATNumber string `valid:"required_if(Country|DE|FR|IT)"`
+	State     string `valid:"required_unless(Country|DE|FR|IT)"`
+	Phone     string `valid:"required_without(Email)"`
+	Email     string `valid:"email,required_without(Phone)"`
+	Street    string `valid:"-"`
+	ZipCode   string `valid:"numeric,required_with(Street)~Zip code is required with a street"`
+}
+
+func TestConditionalRequired(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		param     conditionalRequiredStruct
+		expected  bool
+		validator string
+	}{
+		{conditionalRequiredStruct{Country: "DE", VATNumber: "DE123", Phone: "123"}, true, ""},
+		{conditionalRequiredStruct{Country: "DE", Phone: "123"}, false, "required_if"},
+		{conditionalRequiredStruct{Country: "US", State: "CA", Phone: "123"}, true, ""},
+		{conditionalRequiredStruct{Country: "US", Phone: "123"}, false, "required_unless"},
+		{conditionalRequiredStruct{Country: "DE", VATNumber: "DE123", Email: "foo@bar.com"}, true, ""},
+		{conditionalRequiredStruct{Country: "DE", VATNumber: "DE123"}, false, "required_without"},
+		{conditionalRequiredStruct{Country: "DE", VATNumber: "DE123", Phone: "123", Street: "Main St"}, false, "required_with"},
+		{conditionalRequiredStruct{Country: "DE", VATNumber: "DE123", Phone: "123", Street: "Main St", ZipCode: "12345"}, true, ""},
+		{conditionalRequiredStruct{Country: "DE", VATNumber: "DE123", Phone: "123", ZipCode: "abc"}, false, "numeric"},
+	}
+	for i, test := range tests {
+		actual, err := ValidateStruct(test.param)
+		if actual != test.expected {
+			t.Errorf("Test %d: expected ValidateStruct(%v) to be %v, got %v (%v)", i, test.param, test.expected, actual, err)
+			continue
+		}
+		if test.validator == "" {
+			continue
+		}
+		for _, e := range err.(Errors) {
+			if e.(Error).Validator != test.validator {
+				t.Errorf("Test %d: expected %s error, got %v", i, test.validator, e)
+			}
+		}
+	}
+}
+
+func TestConditionalRequiredErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	_, err := ValidateStruct(conditionalRequiredStruct{Country: "DE", Phone: "123"})
+	if err == nil || err.Error() != "VATNumber: non zero value required by required_if(Country|DE|FR|IT)" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+	_, err = ValidateStruct(conditionalRequiredStruct{Country: "DE", VATNumber: "DE123", Phone: "123", Street: "Main St"})
+	if err == nil || err.Error() != "Zip code is required with a street" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+}
+
+func TestConditionalRequiredFieldsRequiredByDefault(t *testing.T) {
+	t.Parallel()
+
+	v := New(WithFieldsRequiredByDefault(true))
+	if ok, err := v.ValidateStruct(conditionalRequiredStruct{Country: "DE", VATNumber: "DE123", Phone: "123"}); !ok {
+		t.Errorf("Expected fields with unmet conditions to be optional, got %v", err)
+	}
+}
+
+func TestConditionalRequiredValidateMap(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]interface{}{
+		"country": "required",
+		"vat":     "required_if(country|DE)",
+		"phone":   "required_without(email)",
+		"email":   "email,required_without(phone)",
+	}
+
+	var tests = []struct {
+		param     map[string]interface{}
+		expected  bool
+		validator string
+	}{
+		{map[string]interface{}{"country": "DE", "vat": "DE123", "phone": "123"}, true, ""},
+		{map[string]interface{}{"country": "DE", "phone": "123"}, false, "required_if"},
+		{map[string]interface{}{"country": "DE", "vat": "", "phone": "123"}, false, "required_if"},
+		{map[string]interface{}{"country": "US"}, false, "required_without"},
+		{map[string]interface{}{"country": "US", "email": "foo@bar.com"}, true, ""},
+	}
+	for i, test := range tests {
+		actual, err := ValidateMap(test.param, schema)
+		if actual != test.expected {
+			t.Errorf("Test %d: expected ValidateMap(%v) to be %v, got %v (%v)", i, test.param, test.expected, actual, err)
+			continue
+		}
+		if test.validator == "" {
+			continue
+		}
+		for _, e := range err.(Errors) {
+			if e.(Error).Validator != test.validator {
+				t.Errorf("Test %d: expected %s error, got %v", i, test.validator, e)
+			}
+		}
+	}
+}
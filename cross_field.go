@@ -0,0 +1,164 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// crossFieldTagRegexp matches the tags comparing a field with a sibling field, e.g. `eqfield(Password)`.
+var crossFieldTagRegexp = regexp.MustCompile(`^((?:eq|ne|gt|gte|lt|lte)field)\((.+)\)$`)
+
+// crossFieldComparators maps cross-field tags to the check applied to the result of compareValues.
+var crossFieldComparators = map[string]func(cmp int) bool{
+	"eqfield":  func(cmp int) bool { return cmp == 0 },
+	"nefield":  func(cmp int) bool { return cmp != 0 },
+	"gtfield":  func(cmp int) bool { return cmp > 0 },
+	"gtefield": func(cmp int) bool { return cmp >= 0 },
+	"ltfield":  func(cmp int) bool { return cmp < 0 },
+	"ltefield": func(cmp int) bool { return cmp <= 0 },
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// indirectValue dereferences pointers and interfaces, returning the zero Value for nil.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// resolveFieldPath looks up a dotted path like `Address.Country` starting at the struct or map o.
+func resolveFieldPath(o reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		o = indirectValue(o)
+		switch o.Kind() {
+		case reflect.Struct:
+			field, ok := o.Type().FieldByName(name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			o = fieldByIndex(o, field.Index)
+		case reflect.Map:
+			if o.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			o = o.MapIndex(reflect.ValueOf(name).Convert(o.Type().Key()))
+		default:
+			return reflect.Value{}, false
+		}
+		if !o.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+	return o, true
+}
+
+// compareValues compares strings, numbers and time.Time values.
+// The second result is false when a and b can't be compared with each other.
+func compareValues(a, b reflect.Value) (int, bool) {
+	a, b = indirectValue(a), indirectValue(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	if a.Type() == timeType || b.Type() == timeType {
+		if a.Type() != b.Type() || !a.CanInterface() || !b.CanInterface() {
+			return 0, false
+		}
+		ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case ta.Before(tb):
+			return -1, true
+		case ta.After(tb):
+			return 1, true
+		}
+		return 0, true
+	}
+
+	switch {
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return compareOrdered(a.Int() < b.Int(), a.Int() > b.Int()), true
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return compareOrdered(a.Uint() < b.Uint(), a.Uint() > b.Uint()), true
+	case isNumericKind(a.Kind()) && isNumericKind(b.Kind()):
+		fa, fb := toFloat64(a), toFloat64(b)
+		return compareOrdered(fa < fb, fa > fb), true
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		return compareOrdered(!a.Bool() && b.Bool(), a.Bool() && !b.Bool()), true
+	}
+	return 0, false
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func toFloat64(v reflect.Value) float64 {
+	switch {
+	case isIntKind(v.Kind()):
+		return float64(v.Int())
+	case isUintKind(v.Kind()):
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// checkCrossField applies a cross-field validator to v, resolving the other field from o.
+// The failure message names the field instead of quoting its value, which is often a password.
+func checkCrossField(v reflect.Value, o reflect.Value, name string, vp *validatorPlan) error {
+	otherPath := vp.params[0]
+	other, ok := resolveFieldPath(o, otherPath)
+	if !ok {
+		return Error{Name: name, Err: fmt.Errorf("field %s referenced by %s doesn't exist", otherPath, vp.validator), Validator: vp.name, Path: []string{},
+			Field: name, Code: CodeUnknownField, Params: vp.errorParams(), Value: interfaceOf(v)}
+	}
+	var result bool
+	if cmp, ok := compareValues(v, other); ok {
+		result = crossFieldComparators[vp.key](cmp)
+	} else if vp.key == "eqfield" || vp.key == "nefield" {
+		// values that can't be compared are never equal
+		result = vp.key == "nefield"
+	} else {
+		return Error{Name: name, Err: fmt.Errorf("%s can't be compared with %s", name, otherPath), Validator: vp.name, Path: []string{},
+			Field: name, Code: CodeIncomparable, Params: vp.errorParams(), Value: interfaceOf(v)}
+	}
+	if (!result && !vp.negate) || (result && vp.negate) {
+		return validationError(name, vp, name, name, interfaceOf(v))
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package govalidator
+
+import (
+	"testing"
+	"time"
+)
+
+type crossFieldAddress struct {
+	Country string
+}
+
+type crossFieldStruct struct {
+	Password        string            `valid:"required"`
+	ConfirmPassword string            `valid:"required,eqfield(Password)"`
+	OldPassword     string            `valid:"-"`
+	NewPassword     string            `valid:"nefield(OldPassword)"`
+	Min             int               `valid:"-"`
+	Max             int64             `valid:"gtefield(Min)"`
+	Start           time.Time         `valid:"-"`
+	End             *time.Time        `valid:"gtfield(Start)"`
+	Address         crossFieldAddress `valid:"-"`
+	BillingCountry  string            `valid:"eqfield(Address.Country)"`
+}
+
+func TestCrossFieldValidators(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	before := now.Add(-time.Hour)
+	valid := crossFieldStruct{
+		Password:        "secret",
+		ConfirmPassword: "secret",
+		OldPassword:     "old",
+		NewPassword:     "new",
+		Min:             3,
+		Max:             3,
+		Start:           now,
+		Address:         crossFieldAddress{Country: "DE"},
+		BillingCountry:  "DE",
+	}
+
+	var tests = []struct {
+		mutate    func(s *crossFieldStruct)
+		expected  bool
+		validator string
+	}{
+		{func(s *crossFieldStruct) {}, true, ""},
+		{func(s *crossFieldStruct) { s.ConfirmPassword = "other" }, false, "eqfield"},
+		{func(s *crossFieldStruct) { s.NewPassword = "old" }, false, "nefield"},
+		{func(s *crossFieldStruct) { s.Max = 2 }, false, "gtefield"},
+		{func(s *crossFieldStruct) { s.Max = 4 }, true, ""},
+		{func(s *crossFieldStruct) { s.End = &before }, false, "gtfield"},
+		{func(s *crossFieldStruct) { s.End = &now }, false, "gtfield"},
+		{func(s *crossFieldStruct) { later := now.Add(time.Hour); s.End = &later }, true, ""},
+		{func(s *crossFieldStruct) { s.BillingCountry = "FR" }, false, "eqfield"},
+	}
+	for i, test := range tests {
+		s := valid
+		test.mutate(&s)
+		actual, err := ValidateStruct(s)
+		if actual != test.expected {
+			t.Errorf("Test %d: expected ValidateStruct to be %v, got %v (%v)", i, test.expected, actual, err)
+			continue
+		}
+		if test.validator == "" {
+			continue
+		}
+		e, ok := err.(Errors)[0].(Error)
+		if !ok || e.Validator != test.validator {
+			t.Errorf("Test %d: expected %s error, got %#v", i, test.validator, err)
+		}
+	}
+}
+
+func TestCrossFieldErrorMentionsBothFields(t *testing.T) {
+	t.Parallel()
+
+	type Signup struct {
+		Password string `valid:"-"`
+		Confirm  string `valid:"eqfield(Password)"`
+	}
+	type Missing struct {
+		Missing string `valid:"ltfield(Unknown)"`
+	}
+	type Mismatch struct {
+		Mismatch string `valid:"ltfield(Count)"`
+		Count    int    `valid:"-"`
+	}
+
+	// the message names the field instead of quoting the value
+	_, err := ValidateStruct(Signup{Password: "hunter2", Confirm: "hunter3"})
+	if err == nil || err.Error() != "Confirm: Confirm does not validate as eqfield(Password)" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+	_, err = ValidateStruct(Missing{Missing: "a"})
+	if err == nil || err.Error() != "Missing: field Unknown referenced by ltfield(Unknown) doesn't exist" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+	_, err = ValidateStruct(Mismatch{Mismatch: "a", Count: 1})
+	if err == nil || err.Error() != "Mismatch: Mismatch can't be compared with Count" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+}
+
+func TestCrossFieldEmptyValues(t *testing.T) {
+	t.Parallel()
+
+	type Signup struct {
+		Password string     `valid:"-"`
+		Confirm  string     `valid:"eqfield(Password)"`
+		Min      int        `valid:"-"`
+		Max      int        `valid:"gtefield(Min)"`
+		Start    time.Time  `valid:"-"`
+		End      *time.Time `valid:"gtfield(Start)"`
+		Until    time.Time  `valid:"optional,gtfield(Start)"`
+	}
+
+	var tests = []struct {
+		param    Signup
+		expected bool
+	}{
+		{Signup{}, true},
+		// empty fields are compared too
+		{Signup{Password: "secret"}, false},
+		{Signup{Min: 1}, false},
+		{Signup{Min: -1}, true},
+		// nil pointers are absent, not compared
+		{Signup{Start: time.Now()}, true},
+		// optional fields are only compared when set
+		{Signup{Start: time.Now(), Until: time.Now().Add(-time.Hour)}, false},
+	}
+	for _, test := range tests {
+		actual, err := ValidateStruct(test.param)
+		if actual != test.expected {
+			t.Errorf("Expected ValidateStruct(%+v) to be %v, got %v (%v)", test.param, test.expected, actual, err)
+		}
+	}
+}
+
+func TestCrossFieldValidateMap(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]interface{}{
+		"min": "required",
+		"max": "required,gtfield(min)",
+	}
+	if ok, err := ValidateMap(map[string]interface{}{"min": 1, "max": 2}, schema); !ok {
+		t.Errorf("Expected map to be valid, got %v", err)
+	}
+	if ok, _ := ValidateMap(map[string]interface{}{"min": 2, "max": 1.5}, schema); ok {
+		t.Error("Expected map to be invalid")
+	}
+}
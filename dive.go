@@ -0,0 +1,122 @@
+package govalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// splitDiveTag splits a tag like `length(1|10),dive,keys,alpha,endkeys,url` into the options
+// applied to the collection itself, to the map keys and to every element.
+// ok is false when the tag has no dive option.
+func splitDiveTag(tag string) (collection string, keys *string, elements string, ok bool) {
+	raws, _ := splitTagOptions(tag)
+	options := make([]string, len(raws))
+	for i, raw := range raws {
+		options[i] = raw.text
+	}
+	for i, option := range options {
+		if option != "dive" {
+			continue
+		}
+		rest := options[i+1:]
+		if len(rest) > 0 && rest[0] == "keys" {
+			for j := 1; j < len(rest); j++ {
+				if rest[j] == "endkeys" {
+					keysTag := strings.Join(rest[1:j], ",")
+					keys = &keysTag
+					rest = rest[j+1:]
+					break
+				}
+			}
+		}
+		return strings.Join(options[:i], ","), keys, strings.Join(rest, ","), true
+	}
+	return tag, nil, "", false
+}
+
+// keyMarker is appended to the names of map keys in error paths, so that the errors of
+// the key "home" are reported for "Links.home[key]" and those of its value for "Links.home".
+const keyMarker = "[key]"
+
+// checkCollection applies the options placed before dive to the collection v as a whole.
+// Custom type and interface param validators, such as minitems, are applied by typeCheck
+// like for any other kind; length checks the number of items, and the other validators
+// can't be applied to a collection, as CheckStructTags reports.
+func checkCollection(v reflect.Value, f *fieldPlan, consumed []bool) error {
+	for i := range f.validators {
+		vp := &f.validators[i]
+		if vp.kind != paramValidator && vp.kind != tagValidator {
+			continue
+		}
+		consumed[i] = true
+		if vp.key != "length" {
+			return Error{Name: f.name, Err: fmt.Errorf("Validator %s can't be applied to a collection, only to its elements after dive", vp.validator), Validator: vp.name, Path: []string{},
+				Field: f.name, Code: CodeUnsupportedKind, Value: interfaceOf(v)}
+		}
+
+		min, _ := ToInt(vp.params[0])
+		max, _ := ToInt(vp.params[1])
+		if result := v.Len() >= int(min) && v.Len() <= int(max); (!result && !vp.negate) || (result && vp.negate) {
+			count := strconv.Itoa(v.Len()) + " items"
+			return validationError(f.name, vp, count, count, interfaceOf(v))
+		}
+	}
+	return nil
+}
+
+// checkElements validates every element of the slice, array or map v against the options
+// placed after dive, and the map keys against the options between keys and endkeys.
+func (sv *StructValidator) checkElements(ctx context.Context, v reflect.Value, f *fieldPlan, o reflect.Value) (bool, error) {
+	result := true
+	var errs Errors
+	check := func(elem reflect.Value, name string, plan *tagPlan) {
+		ef := f.element(name, plan)
+		if elem.Kind() == reflect.Interface && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+		if (elem.Kind() == reflect.Struct ||
+			(elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Struct)) &&
+			plan.tag != "-" {
+			structResult, err := sv.validateStructValue(ctx, elem)
+			if err != nil {
+				errs = append(errs, prependPathToErrors(err, f.path(name)...))
+			}
+			result = result && structResult
+		}
+		if plan.tag == "" {
+			return
+		}
+		resultItem, err := sv.typeCheck(ctx, elem, ef, o, nil)
+		if err != nil {
+			errs = append(errs, elementErrors(err, ef))
+		}
+		result = result && resultItem
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, k := range keys {
+			if f.keys != nil {
+				check(k, fmt.Sprint(k)+keyMarker, f.keys)
+			}
+			check(v.MapIndex(k), fmt.Sprint(k), f.dive)
+		}
+	default:
+		for i := 0; i < v.Len(); i++ {
+			check(v.Index(i), strconv.Itoa(i), f.dive)
+		}
+	}
+
+	if len(errs) > 0 {
+		return false, errs
+	}
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package govalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitDiveTag(t *testing.T) {
+	t.Parallel()
+
+	alpha := "alpha"
+	var tests = []struct {
+		tag        string
+		collection string
+		keys       *string
+		elements   string
+		ok         bool
+	}{
+		{"email", "email", nil, "", false},
+		{"length(1|10),dive,email", "length(1|10)", nil, "email", true},
+		{"dive,keys,alpha,endkeys,url", "", &alpha, "url", true},
+		{"required,dive,dive,email", "required", nil, "dive,email", true},
+		{"dive,keys,alpha", "", nil, "keys,alpha", true},
+	}
+	for _, test := range tests {
+		collection, keys, elements, ok := splitDiveTag(test.tag)
+		if collection != test.collection || !reflect.DeepEqual(keys, test.keys) || elements != test.elements || ok != test.ok {
+			t.Errorf("Expected splitDiveTag(%q) to be %q %v %q %v, got %q %v %q %v", test.tag,
+				test.collection, test.keys, test.elements, test.ok, collection, keys, elements, ok)
+		}
+	}
+}
+
+func TestValidateStructDive(t *testing.T) {
+	t.Parallel()
+
+	type Item struct {
+		Name string `valid:"required"`
+	}
+	type Dive struct {
+		Emails  []string            `valid:"length(1|3),dive,email"`
+		Matrix  [][]string          `valid:"dive,length(1|2),dive,alpha"`
+		Links   map[string]string   `valid:"dive,keys,alpha,endkeys,url"`
+		Items   []*Item             `valid:"dive,required"`
+		Scores  map[string][]string `valid:"dive,dive,numeric"`
+		Numbers []int               `valid:"dive,range(1|10)"`
+	}
+	valid := func() Dive {
+		return Dive{
+			Emails:  []string{"foo@bar.com", "bar@foo.com"},
+			Matrix:  [][]string{{"a", "b"}, {"c"}},
+			Links:   map[string]string{"home": "https://example.com"},
+			Items:   []*Item{{Name: "a"}},
+			Scores:  map[string][]string{"math": {"1", "2"}},
+			Numbers: []int{1, 10},
+		}
+	}
+
+	var tests = []struct {
+		mutate   func(d *Dive)
+		expected string
+	}{
+		{func(d *Dive) {}, ""},
+		{func(d *Dive) { d.Emails = nil }, "Emails: 0 items does not validate as length(1|3)"},
+		{func(d *Dive) { d.Emails = append(d.Emails, "baz@foo.com", "qux@foo.com") }, "Emails: 4 items does not validate as length(1|3)"},
+		{func(d *Dive) { d.Emails[1] = "invalid" }, "Emails.1: invalid does not validate as email"},
+		{func(d *Dive) { d.Matrix[1] = []string{"c", "d", "e"} }, "Matrix.1: 3 items does not validate as length(1|2)"},
+		{func(d *Dive) { d.Matrix[0][1] = "1" }, "Matrix.0.1: 1 does not validate as alpha"},
+		{func(d *Dive) { d.Links["h0me"] = "https://example.com" }, "Links.h0me[key]: h0me does not validate as alpha"},
+		{func(d *Dive) { d.Links["home"] = "not a url" }, "Links.home: not a url does not validate as url"},
+		{func(d *Dive) { d.Links["h0me"] = "not a url" }, "Links.h0me: not a url does not validate as url;Links.h0me[key]: h0me does not validate as alpha"},
+		{func(d *Dive) { d.Items = append(d.Items, nil) }, "Items.1: non zero value required"},
+		{func(d *Dive) { d.Items[0].Name = "" }, "Items.0.Name: non zero value required;Items.0: non zero value required"},
+		{func(d *Dive) { d.Scores["art"] = []string{"x"} }, "Scores.art.0: x does not validate as numeric"},
+		{func(d *Dive) { d.Numbers[0] = 11 }, "Numbers.0: 11 does not validate as range(1|10)"},
+	}
+	for i, test := range tests {
+		d := valid()
+		test.mutate(&d)
+		ok, err := ValidateStruct(d)
+		if test.expected == "" {
+			if !ok || err != nil {
+				t.Errorf("Test %d: expected struct to be valid, got %v", i, err)
+			}
+			continue
+		}
+		if ok || err == nil || err.Error() != test.expected {
+			t.Errorf("Test %d: expected error %q, got %v", i, test.expected, err)
+		}
+	}
+}
+
+func TestValidateStructDiveInvalidUse(t *testing.T) {
+	t.Parallel()
+
+	type DiveScalar struct {
+		Name string `valid:"dive,email"`
+	}
+	type InvalidDive struct {
+		Email []string `valid:"email,dive,email"`
+	}
+
+	_, err := ValidateStruct(DiveScalar{Name: "foo"})
+	if err == nil || err.Error() != "Name: Validator dive doesn't support kind string" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+	_, err = ValidateStruct(InvalidDive{Email: []string{"foo@bar.com"}})
+	if err == nil || err.Error() != "Email: Validator email can't be applied to a collection, only to its elements after dive" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+}
+
+func TestValidateStructDiveJSONName(t *testing.T) {
+	t.Parallel()
+
+	type Tagged struct {
+		Tags   []string          `valid:"dive,alpha" json:"tags"`
+		Matrix [][]string        `valid:"dive,dive,alpha" json:"matrix"`
+		Labels map[string]string `valid:"dive,alpha"`
+	}
+
+	_, err := ValidateStruct(Tagged{Tags: []string{"a", "1"}})
+	if err == nil || err.Error() != "tags.1: 1 does not validate as alpha" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+
+	_, err = ValidateStruct(Tagged{Tags: []string{"1"}, Matrix: [][]string{{"a"}, {"b", "2"}}, Labels: map[string]string{"k": "3"}})
+	var expected = []struct {
+		name, field string
+		path        []string
+	}{
+		{"tags", "Tags", []string{"tags", "0"}},
+		{"matrix", "Matrix", []string{"matrix", "1", "1"}},
+		{"Labels", "Labels", []string{"Labels", "k"}},
+	}
+	errs := flattenErrors(err)
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %v", len(expected), err)
+	}
+	for i, test := range expected {
+		actual := errs[i].(Error)
+		if actual.Name != test.name || actual.Field != test.field || !reflect.DeepEqual(actual.Path, test.path) {
+			t.Errorf("Expected error %d to be named %q %q at %v, got %q %q at %v", i, test.name, test.field, test.path, actual.Name, actual.Field, actual.Path)
+		}
+	}
+	if expected := "Labels.k: 3 does not validate as alpha;matrix.1.1: 2 does not validate as alpha;tags.0: 1 does not validate as alpha"; err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,201 @@
+package govalidator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type embeddedTimestamps struct {
+	CreatedAt string `valid:"rfc3339" json:"created_at"`
+}
+
+type EmbeddedAudit struct {
+	Author string `valid:"required" json:"author"`
+}
+
+type EmbeddedContact struct {
+	Email string
+}
+
+type embeddedSecret struct {
+	token string `valid:"alphanum,required"`
+}
+
+func TestValidateStructEmbedded(t *testing.T) {
+	t.Parallel()
+
+	type Document struct {
+		embeddedTimestamps
+		*EmbeddedAudit
+		Title string `valid:"required" json:"title"`
+	}
+	type Named struct {
+		EmbeddedAudit `json:"audit"`
+	}
+	type Tagged struct {
+		*EmbeddedAudit `valid:"required"`
+	}
+
+	var tests = []struct {
+		param    interface{}
+		expected map[string]string
+	}{
+		{Document{embeddedTimestamps{"2020-01-01T00:00:00Z"}, &EmbeddedAudit{"me"}, "doc"}, map[string]string{}},
+		{Document{embeddedTimestamps{"yesterday"}, &EmbeddedAudit{}, "doc"}, map[string]string{
+			"created_at": "yesterday does not validate as rfc3339",
+			"author":     "non zero value required",
+		}},
+		{Document{Title: "doc"}, map[string]string{"author": "non zero value required"}},
+		{Named{}, map[string]string{"audit.author": "non zero value required"}},
+		{Tagged{}, map[string]string{
+			"author":        "non zero value required",
+			"EmbeddedAudit": "non zero value required",
+		}},
+	}
+	for _, test := range tests {
+		_, err := ValidateStruct(test.param)
+		if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected ValidateStruct(%+v) to report %v, got %v", test.param, test.expected, actual)
+		}
+	}
+}
+
+func TestValidateStructUnexportedFields(t *testing.T) {
+	t.Parallel()
+
+	type Session struct {
+		embeddedSecret
+		id   string `valid:"uuid"`
+		User string `valid:"required"`
+	}
+	session := Session{embeddedSecret{"!"}, "1", "me"}
+
+	if ok, err := ValidateStruct(session); !ok {
+		t.Errorf("Expected unexported fields to be skipped by default, got %v", err)
+	}
+
+	v := New(WithUnexportedFields(true))
+	expected := map[string]string{
+		"token": "! does not validate as alphanum",
+		"id":    "1 does not validate as uuid",
+	}
+	for _, param := range []interface{}{session, &session} {
+		_, err := v.ValidateStruct(param)
+		if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Expected ValidateStruct(%+v) to report %v, got %v", param, expected, actual)
+		}
+	}
+}
+
+func TestValidateStructUnexportedFieldsSetting(t *testing.T) {
+	t.Parallel()
+
+	type Session struct {
+		id string `valid:"uuid"`
+	}
+
+	v := New()
+	if ok, err := v.ValidateStruct(Session{"1"}); !ok {
+		t.Errorf("Expected unexported fields to be skipped, got %v", err)
+	}
+	// as done by SetUnexportedFields on the default validator
+	v.unexportedFields = true
+	if ok, _ := v.ValidateStruct(Session{"1"}); ok {
+		t.Error("Expected the plan to be compiled again with the unexported fields")
+	}
+}
+
+func TestValidateStructUnexportedFieldKinds(t *testing.T) {
+	t.Parallel()
+
+	type Item struct {
+		Name string `valid:"alpha"`
+		code string `valid:"numeric"`
+	}
+	type Order struct {
+		tags    []string          `valid:"minitems(1),unique,dive,alpha"`
+		labels  map[string]string `valid:"dive,keys,alpha,endkeys,numeric"`
+		count   *int              `valid:"range(1|10)"`
+		item    Item
+		items   []Item    `valid:"minitems(1)"`
+		created time.Time `valid:"required"`
+		ref     interface{}
+		span    validatableRange
+	}
+
+	count := 11
+	order := Order{
+		tags:   []string{"a", "a", "1"},
+		labels: map[string]string{"k1": "x"},
+		count:  &count,
+		item:   Item{Name: "1", code: "x"},
+		items:  []Item{{Name: "2", code: "y"}},
+		ref:    &Item{Name: "3"},
+		span:   validatableRange{From: 5, To: 1},
+	}
+	expected := map[string]string{
+		"tags":           "[a a 1] does not validate as unique",
+		"tags.2":         "1 does not validate as alpha",
+		"labels.k1[key]": "k1 does not validate as alpha",
+		"labels.k1":      "x does not validate as numeric",
+		"count":          "11 does not validate as range(1|10)",
+		"item.Name":      "1 does not validate as alpha",
+		"item.code":      "x does not validate as numeric",
+		"items.0.Name":   "2 does not validate as alpha",
+		"items.0.code":   "y does not validate as numeric",
+		"created":        "non zero value required",
+		"ref.Name":       "3 does not validate as alpha",
+		"span":           "from must not be after to",
+	}
+	_, err := New(WithUnexportedFields(true), WithAllErrors(true)).ValidateStruct(&order)
+	if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected ValidateStruct to report\n%v\ngot\n%v", expected, actual)
+	}
+}
+
+func TestReadableValue(t *testing.T) {
+	t.Parallel()
+
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	type List struct {
+		names []string
+		head  *Node
+		ch    chan int
+	}
+
+	node := &Node{Name: "a"}
+	list := reflect.ValueOf(List{names: []string{"a"}, head: &Node{Name: "b", Next: node}})
+	if v := readableValue(list.Field(0)); !v.CanInterface() || !reflect.DeepEqual(v.Interface(), []string{"a"}) {
+		t.Errorf("Expected a copy of the unexported slice, got %v", v)
+	}
+	if v := readableValue(list.Field(1)); !v.CanInterface() || v.Interface().(*Node).Next.Name != "a" {
+		t.Errorf("Expected a copy of the unexported pointer, got %v", v)
+	}
+	if v := readableValue(list.Field(2)); v.CanInterface() {
+		t.Error("Expected the unexported channel to be left read-only")
+	}
+	node.Next = node
+	list = reflect.ValueOf(List{head: node})
+	if v := readableValue(list.Field(1)); v.CanInterface() {
+		t.Error("Expected the cyclic value to be left read-only")
+	}
+}
+
+func TestResolveFieldPathEmbedded(t *testing.T) {
+	t.Parallel()
+
+	type Form struct {
+		*EmbeddedContact
+		Backup string `valid:"nefield(Email)"`
+	}
+	if ok, err := ValidateStruct(Form{Backup: "me@example.com"}); !ok {
+		t.Errorf("Expected nil embedded pointer to compare as empty, got %v", err)
+	}
+	if ok, _ := ValidateStruct(Form{EmbeddedContact: &EmbeddedContact{"me@example.com"}, Backup: "me@example.com"}); ok {
+		t.Error("Expected promoted field to be compared")
+	}
+}
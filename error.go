@@ -1,6 +1,7 @@
 package govalidator
 
 import (
+	"encoding/json"
 	"sort"
 	"strings"
 )
@@ -13,6 +14,12 @@ func (es Errors) Errors() []error {
 	return es
 }
 
+// Unwrap returns the errors so errors.Is and errors.As look into every one of them
+// (Go 1.20 or later).
+func (es Errors) Unwrap() []error {
+	return es
+}
+
 func (es Errors) Error() string {
 	var errs []string
 	for _, e := range es {
@@ -31,17 +38,156 @@ type Error struct {
 	// Validator indicates the name of the validator that failed
 	Validator string
 	Path      []string
+
+	// Field is the struct field name (or map key) while Name is the JSON name
+	// of the field when it has one. For collection elements, both name the
+	// collection field, the index or key of the element being in Path.
+	Field string
+	// Code is a stable machine-readable identifier of the failure: the name of the
+	// failing validator prefixed with "not_" when negated, or one of the Code constants.
+	Code string
+	// Params holds the parsed validator params, e.g. ["8", "64"] for stringlength(8|64).
+	Params []string
+	// Value is the offending value. It is nil for redacted fields, see SetRedactedValues.
+	Value interface{}
+
+	// element is set for the errors of collection elements, whose Path ends with the name of
+	// the field and the index or key of the element, e.g. ["tags", "1"], see ElementErrors
+	element bool
 }
 
+// Codes of the failures that are not reported by a single validator.
+const (
+	CodeRequired         = "required"
+	CodeNoValidator      = "no_validator"
+	CodeInvalidValidator = "invalid_validator"
+	CodeUnsupportedKind  = "unsupported_kind"
+	CodeUnknownField     = "unknown_field"
+	CodeIncomparable     = "incomparable"
+	CodeValidate         = "validate"
+)
+
 func (e Error) Error() string {
 	if e.CustomErrorMessageExists {
 		return e.Err.Error()
 	}
 
-	errName := e.Name
-	if len(e.Path) > 0 {
-		errName = strings.Join(append(e.Path, e.Name), ".")
+	errName := e.fullName()
+	if errName == "" {
+		// reported by Validatable for the root struct
+		return e.Err.Error()
 	}
-
 	return errName + ": " + e.Err.Error()
 }
+
+// Unwrap returns the underlying error.
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel error of the failure, e.g. ErrEmail.
+func (e Error) Is(target error) bool {
+	if sentinel, ok := sentinelErrors[e.Code]; ok && sentinel == target {
+		return true
+	}
+	return target == ErrRequired && strings.HasPrefix(e.Code, "required_")
+}
+
+// fullName returns the dotted path of the field, e.g. "Address.Street".
+// The name is empty for the errors of a whole struct.
+func (e Error) fullName() string {
+	if len(e.Path) == 0 {
+		return e.Name
+	}
+	if e.Name == "" || e.element {
+		return strings.Join(e.Path, ".")
+	}
+	return strings.Join(append(append([]string{}, e.Path...), e.Name), ".")
+}
+
+// valueMessage is the message of an Error quoting the offending value. MarshalJSON
+// serializes redacted instead, the message naming the field in place of the value,
+// so that values don't leak into responses.
+type valueMessage struct {
+	message  string
+	redacted string
+}
+
+func (m valueMessage) Error() string {
+	return m.message
+}
+
+// valueError formats the message, which may be a custom message, with str standing for the
+// value and the args, and the redacted message with label standing for the value.
+// Like TruncatingErrorf, extra args are dropped.
+func valueError(format string, str, label string, args ...interface{}) error {
+	return valueMessage{
+		message:  TruncatingErrorf(format, append([]interface{}{str}, args...)...).Error(),
+		redacted: TruncatingErrorf(format, append([]interface{}{label}, args...)...).Error(),
+	}
+}
+
+// errorJSON is the JSON representation of an Error.
+type errorJSON struct {
+	Path      string   `json:"path"`
+	Field     string   `json:"field"`
+	Validator string   `json:"validator"`
+	Code      string   `json:"code"`
+	Message   string   `json:"message"`
+	Params    []string `json:"params"`
+}
+
+func newErrorJSON(err error) errorJSON {
+	e, ok := err.(Error)
+	if !ok {
+		return errorJSON{Message: err.Error(), Params: []string{}}
+	}
+	ej := errorJSON{
+		Path:      e.fullName(),
+		Field:     e.Name,
+		Validator: e.Validator,
+		Code:      e.Code,
+		Params:    e.Params,
+	}
+	if m, ok := e.Err.(valueMessage); ok {
+		ej.Message = m.redacted
+	} else if e.Err != nil {
+		ej.Message = e.Err.Error()
+	}
+	if ej.Params == nil {
+		ej.Params = []string{}
+	}
+	return ej
+}
+
+// MarshalJSON encodes the error as an object with the path, field, validator, code,
+// message and params members. The value is left out so it doesn't leak into responses:
+// the message names the field instead of quoting the value, e.g.
+// "password does not validate as stringlength(8|64)".
+func (e Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newErrorJSON(e))
+}
+
+// MarshalJSON encodes the errors as a flat array of Error objects, in the order they were
+// reported. Errors other than Error are encoded with only the message member set.
+func (es Errors) MarshalJSON() ([]byte, error) {
+	flat := flattenErrors(es)
+	out := make([]errorJSON, len(flat))
+	for i, err := range flat {
+		out[i] = newErrorJSON(err)
+	}
+	return json.Marshal(out)
+}
+
+// flattenErrors returns the errors held by err, expanding nested Errors in order.
+func flattenErrors(err error) []error {
+	es, ok := err.(Errors)
+	if !ok {
+		return []error{err}
+	}
+	var flat []error
+	for _, e := range es {
+		flat = append(flat, flattenErrors(e)...)
+	}
+	return flat
+}
@@ -1,7 +1,12 @@
 package govalidator
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -27,3 +32,217 @@ func TestErrorsToString(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorStructuredFields(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Password string `valid:"stringlength(8|64)" json:"password"`
+		Secret   string `valid:"!alpha,redact" json:"secret"`
+		Email    string `valid:"required"`
+		Age      int    `valid:"range(18|99)"`
+	}
+
+	_, err := ValidateStruct(Account{Password: "short", Secret: "letters", Age: 7})
+	if err == nil {
+		t.Fatal("Expected validation to fail")
+	}
+
+	var tests = []struct {
+		expected Error
+	}{
+		{Error{Name: "password", Field: "Password", Validator: "stringlength", Code: "stringlength", Params: []string{"8", "64"}, Value: "short"}},
+		{Error{Name: "secret", Field: "Secret", Validator: "!alpha", Code: "not_alpha"}},
+		{Error{Name: "Email", Field: "Email", Validator: "required", Code: CodeRequired, Value: ""}},
+		{Error{Name: "Age", Field: "Age", Validator: "range", Code: "range", Params: []string{"18", "99"}, Value: 7}},
+	}
+	errs := err.(Errors)
+	if len(errs) != len(tests) {
+		t.Fatalf("Expected %d errors, got %v", len(tests), err)
+	}
+	for i, test := range tests {
+		actual := errs[i].(Error)
+		actual.Err, actual.Path = nil, nil
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected error %d to be %#v, got %#v", i, test.expected, actual)
+		}
+	}
+}
+
+func TestErrorRedactedValues(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Password string `valid:"stringlength(8|64)"`
+	}
+
+	_, err := New(WithRedactedValues(true)).ValidateStruct(Account{Password: "short"})
+	if actual := err.(Errors)[0].(Error); actual.Value != nil || actual.Params == nil {
+		t.Errorf("Expected the value to be redacted and the params to be kept, got %#v", actual)
+	}
+	if expected := "Password: Password does not validate as stringlength(8|64)"; err.Error() != expected {
+		t.Errorf("Expected the message to be %q, got %q", expected, err.Error())
+	}
+
+	type Secrets struct {
+		PIN    string            `valid:"numeric~%s is not a PIN,redact" json:"pin"`
+		Tokens []string          `valid:"redact,dive,hexadecimal"`
+		Keys   map[string]string `valid:"redact,dive,keys,alpha,endkeys,hexadecimal"`
+		Nested Account           `valid:"redact"`
+	}
+	_, err = ValidateStruct(Secrets{PIN: "hunter2", Tokens: []string{"hunter2"}, Keys: map[string]string{"k3y": "hunter2"}, Nested: Account{Password: "hunter2"}})
+	var check func(err error)
+	check = func(err error) {
+		if errs, ok := err.(Errors); ok {
+			for _, e := range errs {
+				check(e)
+			}
+			return
+		}
+		if strings.Contains(err.Error(), "hunter2") || err.(Error).Value != nil {
+			t.Errorf("Expected the value to be redacted, got %q %#v", err.Error(), err)
+		}
+	}
+	check(err)
+	if len(ErrorsByPath(err)) != 5 {
+		t.Errorf("Expected every field to fail, got %v", err)
+	}
+	if expected := "pin is not a PIN"; ErrorByField(err, "pin") != expected {
+		t.Errorf("Expected the custom message to be %q, got %q", expected, ErrorByField(err, "pin"))
+	}
+}
+
+func TestErrorParamsAreCopied(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Password string `valid:"stringlength(8|64)"`
+	}
+
+	_, err := ValidateStruct(Account{Password: "short"})
+	err.(Errors)[0].(Error).Params[0] = "1"
+	_, err = ValidateStruct(Account{Password: "short"})
+	if params := err.(Errors)[0].(Error).Params; params[0] != "8" {
+		t.Errorf("Expected the params of the cached plan to be unchanged, got %v", params)
+	}
+}
+
+func TestErrorsMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	type Address struct {
+		Street string `valid:"required" json:"street"`
+	}
+	type User struct {
+		Name    string  `valid:"stringlength(2|10)" json:"name"`
+		Address Address `json:"address"`
+	}
+
+	_, err := ValidateStruct(User{Name: "a"})
+	actual, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	expected := `[` +
+		`{"path":"name","field":"name","validator":"stringlength","code":"stringlength","message":"name does not validate as stringlength(2|10)","params":["2","10"]},` +
+		`{"path":"address.street","field":"street","validator":"required","code":"required","message":"non zero value required","params":[]}` +
+		`]`
+	if string(actual) != expected {
+		t.Errorf("Expected JSON to be\n%s\ngot\n%s", expected, actual)
+	}
+
+	actual, _ = json.Marshal(Errors{fmt.Errorf("plain")})
+	if expected := `[{"path":"","field":"","validator":"","code":"","message":"plain","params":[]}]`; string(actual) != expected {
+		t.Errorf("Expected JSON to be %s, got %s", expected, actual)
+	}
+}
+
+func TestErrorsMarshalJSONLeavesValuesOut(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Password string `valid:"stringlength(8|64)~%s is too short,redact" json:"password"`
+		PIN      string `valid:"numeric" json:"pin"`
+		Token    string `valid:"token"`
+	}
+
+	v := New(WithTranslator(Catalogs{"en": {"numeric": "{value} is not a number"}}), WithLocale("en"))
+	v.AddCustomTypeValidator("token", func(i interface{}, o interface{}) bool {
+		return false
+	})
+	_, err := v.ValidateStruct(Account{Password: "hunter2", PIN: "hunter2", Token: "hunter2"})
+	if len(ErrorsByField(err)) != 3 {
+		t.Fatalf("Expected every field to fail, got %v", err)
+	}
+	errorsJSON, _ := json.Marshal(err)
+	problemJSON, _ := json.Marshal(NewProblemDetails(err))
+	for _, actual := range [][]byte{errorsJSON, problemJSON} {
+		if strings.Contains(string(actual), "hunter2") {
+			t.Errorf("Expected the values to be left out, got %s", actual)
+		}
+	}
+	if !strings.Contains(err.Error(), "hunter2 is not a number") {
+		t.Errorf("Expected the message of unredacted fields to quote the value, got %v", err)
+	}
+}
+
+func TestErrorsIsAs(t *testing.T) {
+	t.Parallel()
+
+	type Address struct {
+		Country string `valid:"ISO3166Alpha2"`
+	}
+	type User struct {
+		Email    string            `valid:"email,required"`
+		Name     string            `valid:"required"`
+		Phone    string            `valid:"required_without(Email)"`
+		Website  string            `valid:"!url"`
+		Address  Address           `valid:"required"`
+		Settings map[int]string    `valid:"required"`
+		Labels   map[string]string `valid:"-"`
+	}
+
+	_, err := ValidateStruct(User{Email: "nope", Name: "Ann", Website: "http://example.com", Address: Address{Country: "XX"}, Settings: map[int]string{1: "a"}})
+
+	var tests = []struct {
+		target   error
+		expected bool
+	}{
+		{ErrEmail, true},
+		{ErrRequired, false},
+		{ErrURL, false},
+		{ErrISO3166Alpha2, true},
+		{ErrUnsupportedType, true},
+		{ErrInt, false},
+	}
+	for _, test := range tests {
+		if actual := errors.Is(err, test.target); actual != test.expected {
+			t.Errorf("Expected errors.Is(err, %v) to be %v, got %v", test.target, test.expected, actual)
+		}
+	}
+
+	var unsupported *UnsupportedTypeError
+	if !errors.As(err, &unsupported) || unsupported.Type.String() != "map[int]string" {
+		t.Errorf("Expected errors.As to find the UnsupportedTypeError, got %v", unsupported)
+	}
+	var e Error
+	if !errors.As(err, &e) || e.Code != "email" {
+		t.Errorf("Expected errors.As to find the first Error, got %v", e)
+	}
+
+	_, err = ValidateStruct(User{Website: "nope", Settings: map[int]string{}})
+	if !errors.Is(err, ErrRequired) || !errors.Is(err, ErrRequiredWithout) {
+		t.Errorf("Expected the required errors to match ErrRequired and ErrRequiredWithout, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ValidateStructCtx(ctx, User{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	wrapped := Error{Name: "Name", Err: ErrRequired, Code: CodeRequired}
+	if errors.Unwrap(wrapped) != ErrRequired {
+		t.Error("Expected Unwrap to return Err")
+	}
+}
@@ -0,0 +1,60 @@
+package govalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError returns the error ValidateStruct reports when the field fails the tag option spec,
+// e.g. "!length(1|10)", whose Error.Code is code and params are params. name is the name of the
+// field in errors, its JSON name when it has one, field its Go name, message the custom message of
+// the option, str the value as passed to the validator and value the value of the field.
+// It is called by the code of govalidator-gen, which resolves the option when generating it.
+func FieldError(name, field, spec, code string, params []string, message, str string, value interface{}) error {
+	vp := validatorPlan{
+		validator:          strings.TrimPrefix(spec, "!"),
+		name:               stripParams(spec),
+		negate:             strings.HasPrefix(spec, "!"),
+		customErrorMessage: message,
+	}
+	err := validationError(field, &vp, str, name, value).(Error)
+	err.Name, err.Code, err.Params = name, code, params
+	return err
+}
+
+// RequiredError returns the error ValidateStruct reports when the required field is empty, with
+// the custom message of the required option if not empty, see FieldError.
+func RequiredError(name, field, message string, value interface{}) error {
+	err := Error{Name: name, Err: fmt.Errorf("non zero value required"), Validator: "required", Path: []string{},
+		Field: field, Code: CodeRequired, Value: value}
+	if message != "" {
+		err.Err, err.CustomErrorMessageExists = errors.New(message), true
+	}
+	return err
+}
+
+// ElementErrors sets the path of the errors reported for a collection element, e.g. "tags" and
+// "1" for the element 1 of the field tags, as ValidateStruct does. The errors keep the name of the
+// field. It is called by the code of govalidator-gen.
+func ElementErrors(err error, path ...string) error {
+	switch err2 := err.(type) {
+	case Error:
+		if !err2.element && len(err2.Path) == 0 {
+			err2.Path, err2.element = append([]string(nil), path...), true
+		}
+		return err2
+	case Errors:
+		for i, err3 := range err2 {
+			err2[i] = ElementErrors(err3, path...)
+		}
+		return err2
+	}
+	return err
+}
+
+// PrependPathToErrors prepends the path segments, e.g. "Items" and "0", to the Path of the
+// Error values of err, as ValidateStruct does for the errors of nested structs.
+func PrependPathToErrors(err error, path ...string) error {
+	return prependPathToErrors(err, path...)
+}
@@ -0,0 +1,70 @@
+package govalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type generatedContact struct {
+	Email string `valid:"required,email" json:"email"`
+	Zip   string `valid:"!numeric~Zip %s is numeric"`
+}
+
+// Validate stands for a method generated by govalidator-gen.
+func (c *generatedContact) Validate() error {
+	var errs Errors
+	switch {
+	case c.Email == "":
+		errs = append(errs, RequiredError("email", "Email", "", c.Email))
+	case !IsEmail(c.Email):
+		errs = append(errs, FieldError("email", "Email", "email", "email", nil, "", c.Email, c.Email))
+	}
+	if c.Zip != "" && IsNumeric(c.Zip) {
+		errs = append(errs, FieldError("Zip", "Zip", "!numeric", "not_numeric", nil, "Zip %s is numeric", c.Zip, c.Zip))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (*generatedContact) GeneratedByGovalidator() {}
+
+func TestGeneratedErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []generatedContact{
+		{},
+		{Email: "bob", Zip: "123"},
+		{Email: "bob@example.com", Zip: "ab"},
+	}
+	for _, c := range tests {
+		_, want := ValidateStruct(c)
+		if got := c.Validate(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Validate(%+v) = %#v, want %#v", c, got, want)
+		}
+	}
+}
+
+func TestRequiredErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	err := RequiredError("email", "Email", "Email is missing", "").(Error)
+	if err.Err.Error() != "Email is missing" || !err.CustomErrorMessageExists || err.Code != CodeRequired {
+		t.Errorf("RequiredError() = %#v", err)
+	}
+}
+
+func TestPrependPathToErrors(t *testing.T) {
+	t.Parallel()
+
+	err := PrependPathToErrors(Errors{Error{Name: "Zip", Path: []string{"home"}}, errors.New("other")}, "items", "0")
+	errs := err.(Errors)
+	if got := errs[0].(Error).Path; !reflect.DeepEqual(got, []string{"items", "0", "home"}) {
+		t.Errorf("Path = %v, want [items 0 home]", got)
+	}
+	if errs[1].Error() != "other" {
+		t.Errorf("errs[1] = %v, want other", errs[1])
+	}
+}
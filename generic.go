@@ -0,0 +1,143 @@
+package govalidator
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Rule is a typed validation rule, it returns nil when value is valid and an Error
+// describing the failure otherwise. ctx is the context given to ValidateWith, which
+// also carries the StructValidator the rules are applied with.
+type Rule[T any] func(ctx context.Context, value T) error
+
+// validatorContextKey holds the StructValidator of ValidateWith in the context of the rules.
+type validatorContextKey struct{}
+
+// validatorFrom returns the StructValidator ValidateWith stored in ctx, or the package-level one.
+func validatorFrom(ctx context.Context) *StructValidator {
+	if sv, ok := ctx.Value(validatorContextKey{}).(*StructValidator); ok {
+		return sv
+	}
+	return defaultValidator
+}
+
+// Validate validates v with the package-level settings. Structs and pointers to structs
+// are validated with their tags first, like ValidateStruct, then the rules are applied in
+// order. It stops at the first failure unless all errors are collected, see SetAllErrors.
+func Validate[T any](v T, rules ...Rule[T]) error {
+	return ValidateWith(context.Background(), defaultValidator, v, rules...)
+}
+
+// ValidateCtx is Validate with a context, see ValidateStructCtx.
+func ValidateCtx[T any](ctx context.Context, v T, rules ...Rule[T]) error {
+	return ValidateWith(ctx, defaultValidator, v, rules...)
+}
+
+// ValidateWith is Validate using the tags and policies configured on sv.
+func ValidateWith[T any](ctx context.Context, sv *StructValidator, v T, rules ...Rule[T]) error {
+	var errs Errors
+	ctx = context.WithValue(ctx, validatorContextKey{}, sv)
+	if val := reflect.Indirect(reflect.ValueOf(v)); val.Kind() == reflect.Struct {
+		if _, err := sv.validateStruct(ctx, val.Interface()); err != nil {
+			if !sv.allErrors {
+				return sv.translateErrors(ctx, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	for _, rule := range rules {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := rule(ctx, v); err != nil {
+			if !sv.allErrors {
+				return sv.translateErrors(ctx, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return sv.translateErrors(ctx, errs)
+	}
+	return nil
+}
+
+// NewRule returns a Rule reporting the validator name when fn returns false.
+func NewRule[T any](name string, fn func(T) bool) Rule[T] {
+	return func(_ context.Context, value T) error {
+		if fn(value) {
+			return nil
+		}
+		return ruleError(name, nil, value)
+	}
+}
+
+// NonZero returns a Rule that fails for the zero value of T, like the `required` tag.
+func NonZero[T comparable]() Rule[T] {
+	return func(_ context.Context, value T) error {
+		var zero T
+		if value != zero {
+			return nil
+		}
+		return Error{Err: fmt.Errorf("non zero value required"), Validator: "required", Path: []string{},
+			Code: CodeRequired, Value: value}
+	}
+}
+
+// InRangeRule returns a Rule that fails unless the value lies between left and right, see InRangeOf.
+func InRangeRule[T cmp.Ordered](left, right T) Rule[T] {
+	return func(_ context.Context, value T) error {
+		if InRangeOf(value, left, right) {
+			return nil
+		}
+		return ruleError("range", []string{fmt.Sprint(left), fmt.Sprint(right)}, value)
+	}
+}
+
+// OneOf returns a Rule that fails unless the value is one of values, like the `in` tag.
+func OneOf[T comparable](values ...T) Rule[T] {
+	params := make([]string, len(values))
+	for i, v := range values {
+		params[i] = fmt.Sprint(v)
+	}
+	return func(_ context.Context, value T) error {
+		for _, v := range values {
+			if value == v {
+				return nil
+			}
+		}
+		return ruleError("in", params, value)
+	}
+}
+
+// Tag returns a Rule applying the options of a `valid` tag, such as "required,email" or
+// "range(1|10)", resolved against the validators of the StructValidator given to ValidateWith,
+// or the package-level validators.
+func Tag[T any](tag string) Rule[T] {
+	return func(ctx context.Context, value T) error {
+		v := reflect.ValueOf(value)
+		if !v.IsValid() {
+			v = reflect.Zero(reflect.TypeOf((*T)(nil)).Elem())
+		}
+		sv := validatorFrom(ctx)
+		plan := sv.tagPlan(tag, "")
+		_, err := sv.typeCheck(ctx, v, &fieldPlan{tagPlan: plan}, reflect.Value{}, nil)
+		if err != nil && sv.isRedacted(plan) {
+			err = redactErrors(err)
+		}
+		return err
+	}
+}
+
+// ruleError builds the error reported when value doesn't pass the validator name.
+func ruleError(name string, params []string, value interface{}) error {
+	validator := name
+	if len(params) > 0 {
+		validator += "(" + strings.Join(params, "|") + ")"
+	}
+	return Error{Err: fmt.Errorf("%v does not validate as %s", value, validator), Validator: name, Path: []string{},
+		Code: name, Params: append([]string(nil), params...), Value: value}
+}
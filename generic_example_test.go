@@ -0,0 +1,21 @@
+package govalidator
+
+import "fmt"
+
+func ExampleValidate() {
+	type Signup struct {
+		Email string `valid:"email,required"`
+		Age   int
+	}
+
+	signup := Signup{Email: "me@example.com", Age: 12}
+	err := Validate(signup, NewRule("adult", func(s Signup) bool { return s.Age >= 18 }))
+	fmt.Println(err)
+
+	fmt.Println(Validate(7, InRangeRule(1, 5)))
+	fmt.Println(Validate("nope", NonZero[string](), Tag[string]("email")))
+	// Output:
+	// {me@example.com 12} does not validate as adult
+	// 7 does not validate as range(1|5)
+	// nope does not validate as email
+}
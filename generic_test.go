@@ -0,0 +1,93 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateGeneric(t *testing.T) {
+	t.Parallel()
+
+	type Order struct {
+		ID       string `valid:"uuid,required"`
+		Quantity int
+	}
+	valid := Order{ID: "a987fbc9-4bed-3078-cf07-9141ba07c9f3", Quantity: 2}
+	positive := NewRule("positive", func(o Order) bool { return o.Quantity > 0 })
+
+	if err := Validate(valid, positive); err != nil {
+		t.Errorf("Expected Validate(%+v) to succeed, got %v", valid, err)
+	}
+	if err := Validate(&valid, NewRule("set", func(o *Order) bool { return o != nil })); err != nil {
+		t.Errorf("Expected Validate(&%+v) to succeed, got %v", valid, err)
+	}
+	if err := Validate(Order{ID: "1", Quantity: 2}, positive); !errors.Is(err, ErrUUID) {
+		t.Errorf("Expected the struct tags to be validated, got %v", err)
+	}
+	err := Validate(Order{ID: valid.ID}, positive)
+	if e, ok := err.(Error); !ok || e.Code != "positive" || e.Value != (Order{ID: valid.ID}) {
+		t.Errorf("Expected the rule to fail, got %#v", err)
+	}
+
+	err = ValidateWith(context.Background(), New(WithAllErrors(true)), Order{ID: "1"}, positive)
+	if errs, ok := err.(Errors); !ok || len(errs) != 2 {
+		t.Errorf("Expected the struct and rule errors, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ValidateCtx(ctx, 1, InRangeRule(0, 2)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var tests = []struct {
+		err      error
+		expected Error
+	}{
+		{NonZero[string]()(ctx, ""), Error{Validator: "required", Code: CodeRequired, Value: ""}},
+		{NonZero[int]()(ctx, 1), Error{}},
+		{InRangeRule(1.5, 2.5)(ctx, 3), Error{Validator: "range", Code: "range", Params: []string{"1.5", "2.5"}, Value: 3.0}},
+		{InRangeRule("a", "c")(ctx, "b"), Error{}},
+		{OneOf("red", "green")(ctx, "blue"), Error{Validator: "in", Code: "in", Params: []string{"red", "green"}, Value: "blue"}},
+		{OneOf(1, 2)(ctx, 2), Error{}},
+		{NewRule("even", func(i int) bool { return i%2 == 0 })(ctx, 3), Error{Validator: "even", Code: "even", Value: 3}},
+		{Tag[string]("required,email")(ctx, ""), Error{Validator: "required", Code: CodeRequired, Value: ""}},
+		{Tag[string]("email")(ctx, ""), Error{}},
+		{Tag[int]("range(1|10)")(ctx, 11), Error{Validator: "range", Code: "range", Params: []string{"1", "10"}, Value: 11}},
+		{Tag[[]string]("dive,alpha")(ctx, []string{"a"}), Error{}},
+	}
+	for i, test := range tests {
+		var actual Error
+		if test.err != nil {
+			actual = test.err.(Error)
+			actual.Err, actual.Path = nil, nil
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected rule %d to return %#v, got %#v", i, test.expected, actual)
+		}
+	}
+}
+
+func TestTagRuleValidator(t *testing.T) {
+	t.Parallel()
+
+	sv := New()
+	sv.AddValidator("sku", func(str string) bool { return strings.HasPrefix(str, "SKU-") })
+	if err := ValidateWith(context.Background(), sv, "SKU-1", Tag[string]("sku")); err != nil {
+		t.Errorf("Expected the tag to be resolved against sv, got %v", err)
+	}
+	if err := ValidateWith(context.Background(), sv, "1", Tag[string]("sku")); err == nil || err.Error() != "1 does not validate as sku" {
+		t.Errorf("Got an unexpected error: %v", err)
+	}
+	if err := Validate("SKU-1", Tag[string]("sku")); err == nil {
+		t.Error("Expected the tag to be unknown to the package-level validators")
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/asaskevich/govalidator/v12
 
-go 1.13
+go 1.21
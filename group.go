@@ -0,0 +1,82 @@
+package govalidator
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+type groupsContextKey struct{}
+
+// ValidateStructGroups is ValidateStruct applying only the tag options of the given groups,
+// besides the options that have no group. An option is assigned to groups with the `@` suffix:
+//
+//	type User struct {
+//	    Email string `valid:"required@create,email"`
+//	    ID    string `valid:"required@update@delete,uuid"`
+//	}
+//
+// ValidateStructGroups(user, "create") requires Email but not ID. Fields having only options of
+// inactive groups are skipped. The groups apply to nested structs and collection elements too.
+// ValidateStruct skips all the options assigned to a group.
+func ValidateStructGroups(s interface{}, groups ...string) (bool, error) {
+	return defaultValidator.ValidateStructGroups(s, groups...)
+}
+
+// ValidateStructGroupsCtx is the context-aware variant of ValidateStructGroups.
+func ValidateStructGroupsCtx(ctx context.Context, s interface{}, groups ...string) (bool, error) {
+	return defaultValidator.ValidateStructGroupsCtx(ctx, s, groups...)
+}
+
+// ValidateStructGroups validates s using the options of the given groups, see the package-level ValidateStructGroups.
+func (sv *StructValidator) ValidateStructGroups(s interface{}, groups ...string) (bool, error) {
+	return sv.ValidateStructGroupsCtx(context.Background(), s, groups...)
+}
+
+// ValidateStructGroupsCtx is the context-aware variant of ValidateStructGroups.
+func (sv *StructValidator) ValidateStructGroupsCtx(ctx context.Context, s interface{}, groups ...string) (bool, error) {
+	return sv.ValidateStructCtx(context.WithValue(ctx, groupsContextKey{}, groupsKey(groups)), s)
+}
+
+// groupsKey returns the normalized list of groups used to cache the plans, e.g. "create,update".
+func groupsKey(groups []string) string {
+	sorted := append([]string{}, groups...)
+	sort.Strings(sorted)
+	var unique []string
+	for i, group := range sorted {
+		if group != "" && (i == 0 || group != sorted[i-1]) {
+			unique = append(unique, group)
+		}
+	}
+	return strings.Join(unique, ",")
+}
+
+func groupsFromContext(ctx context.Context) string {
+	groups, _ := ctx.Value(groupsContextKey{}).(string)
+	return groups
+}
+
+// filterGroups removes from tag the options assigned to groups that are not active, and strips
+// the groups from the others. all is true when every option of tag was removed.
+func filterGroups(tag string, active string) (filtered string, all bool) {
+	if !strings.Contains(tag, "@") {
+		return tag, false
+	}
+	activeGroups := strings.Split(active, ",")
+	options, _ := splitTagOptions(tag)
+	var kept []string
+	for _, option := range options {
+		opt, groups, err := parseTagOption(option.text, option.column)
+		if err != nil || len(opt.Groups) == 0 {
+			kept = append(kept, option.text)
+			continue
+		}
+		for _, group := range opt.Groups {
+			if IsIn(group, activeGroups...) {
+				kept = append(kept, option.text[:groups[0]]+option.text[groups[1]:])
+				break
+			}
+		}
+	}
+	return strings.Join(kept, ","), len(kept) == 0
+}
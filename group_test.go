@@ -0,0 +1,82 @@
+package govalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterGroups(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		tag      string
+		active   string
+		expected string
+		all      bool
+	}{
+		{"required,email", "create", "required,email", false},
+		{"required@create,email", "create", "required,email", false},
+		{"required@create,email", "update", "email", false},
+		{"required@create,email", "", "email", false},
+		{"required@create@update,email", "update", "required,email", false},
+		{"required@create~Name is blank,email", "create", "required~Name is blank,email", false},
+		{"required~Write to me@example.com,email", "", "required~Write to me@example.com,email", false},
+		{"matches(^.+@.+$)", "", "matches(^.+@.+$)", false},
+		{"matches(^.+@.+$)@create", "create,update", "matches(^.+@.+$)", false},
+		{"required@create,uuid@create", "update", "", true},
+	}
+	for _, test := range tests {
+		actual, all := filterGroups(test.tag, test.active)
+		if actual != test.expected || all != test.all {
+			t.Errorf("Expected filterGroups(%q, %q) to be %q, %v, got %q, %v", test.tag, test.active, test.expected, test.all, actual, all)
+		}
+	}
+}
+
+func TestValidateStructGroups(t *testing.T) {
+	t.Parallel()
+
+	type Address struct {
+		Street string `valid:"required@create"`
+	}
+	type User struct {
+		ID       string   `valid:"required@update,uuid"`
+		Email    string   `valid:"required@create,email"`
+		Password string   `valid:"required@create@password,stringlength(8|64)@create@password"`
+		Address  Address  `valid:"required@create"`
+		Tags     []string `valid:"dive,required@create,alpha"`
+	}
+
+	var tests = []struct {
+		param    User
+		groups   []string
+		expected string
+	}{
+		{User{Email: "a@b.c", Password: "long enough", Address: Address{Street: "Main"}}, []string{"create"}, ""},
+		{User{}, []string{"create"}, "Email: non zero value required;Password: non zero value required;Address.Street: non zero value required;Address: non zero value required"},
+		{User{Password: "short", Tags: []string{""}}, []string{"create"}, "Email: non zero value required;Password: short does not validate as stringlength(8|64);Address.Street: non zero value required;Address: non zero value required;Tags.0: non zero value required"},
+		{User{}, []string{"update"}, "ID: non zero value required"},
+		{User{ID: "1", Email: "nope", Password: "short", Tags: []string{""}}, []string{"update"}, "ID: 1 does not validate as uuid;Email: nope does not validate as email"},
+		{User{Password: "short"}, []string{"password"}, "Password: short does not validate as stringlength(8|64)"},
+		{User{Password: "short"}, []string{"update", "password"}, "ID: non zero value required;Password: short does not validate as stringlength(8|64)"},
+		{User{Password: "short"}, nil, ""},
+	}
+	for _, test := range tests {
+		_, err := ValidateStructGroups(test.param, test.groups...)
+		actual := ""
+		if err != nil {
+			var messages []string
+			for _, e := range flattenErrors(err) {
+				messages = append(messages, e.Error())
+			}
+			actual = strings.Join(messages, ";")
+		}
+		if actual != test.expected {
+			t.Errorf("Expected ValidateStructGroups(%+v, %v) to report\n%q\ngot\n%q", test.param, test.groups, test.expected, actual)
+		}
+	}
+
+	if ok, err := ValidateStruct(User{}); !ok {
+		t.Errorf("Expected ValidateStruct to skip the grouped options, got %v", err)
+	}
+}
@@ -0,0 +1,298 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Translator returns the message of a validation failure in the given locale.
+// The failure is identified by e.Code and e.Params; e.Err holds the English message
+// or the custom message of the `~` tag syntax. ok is false when the translator has
+// no message for e, which then keeps its message.
+type Translator interface {
+	Translate(locale string, e Error) (message string, ok bool)
+}
+
+// Catalog maps failure codes (see Error.Code) to message templates. Custom messages of
+// the `~` tag syntax and the errors of Validatable are looked up as keys instead.
+// The key "*" is the fallback for codes without a template and "not_*" the fallback
+// for negated validators.
+//
+// Templates may use the placeholders {field}, {validator}, {value}, {params} for all
+// params separated by ", ", and {0}, {1}, ... for the params by position.
+type Catalog map[string]string
+
+// Catalogs is a Translator looking up the templates in the Catalog of the locale.
+// A locale like "de-AT" falls back to the catalog of its language "de".
+type Catalogs map[string]Catalog
+
+// Translate implements Translator.
+func (c Catalogs) Translate(locale string, e Error) (string, bool) {
+	catalog, ok := c[locale]
+	if !ok {
+		if i := strings.IndexAny(locale, "-_"); i > 0 {
+			catalog, ok = c[locale[:i]]
+		}
+	}
+	if !ok {
+		return "", false
+	}
+
+	var template string
+	if e.CustomErrorMessageExists || e.Code == CodeValidate {
+		if e.Err == nil {
+			return "", false
+		}
+		if template, ok = catalog[e.Err.Error()]; !ok {
+			return "", false
+		}
+	} else if template, ok = catalog[e.Code]; !ok {
+		if strings.HasPrefix(e.Code, "not_") {
+			template, ok = catalog["not_*"]
+		}
+		if !ok {
+			if template, ok = catalog["*"]; !ok {
+				return "", false
+			}
+		}
+	}
+
+	replacements := []string{
+		"{field}", e.Name,
+		"{validator}", strings.TrimPrefix(e.Validator, "!"),
+		"{params}", strings.Join(e.Params, ", "),
+	}
+	if e.Value != nil {
+		replacements = append(replacements, "{value}", fmt.Sprint(e.Value))
+	} else {
+		replacements = append(replacements, "{value}", "")
+	}
+	for i, param := range e.Params {
+		replacements = append(replacements, "{"+strconv.Itoa(i)+"}", param)
+	}
+	return strings.NewReplacer(replacements...).Replace(template), true
+}
+
+// DefaultCatalogs holds the shipped catalogs for English, German, French and Spanish.
+// Catalogs for other locales can be added to it.
+var DefaultCatalogs = Catalogs{
+	"en": {
+		"*":                  "does not validate as {validator}",
+		"not_*":              "must not validate as {validator}",
+		CodeRequired:         "is required",
+		CodeNoValidator:      "has no validation defined",
+		CodeInvalidValidator: "has an invalid validator {validator}",
+		CodeUnsupportedKind:  "can't be validated by {validator}",
+		CodeUnknownField:     "refers to the unknown field {0}",
+		CodeIncomparable:     "can't be compared with {0}",
+		"required_if":        "is required depending on {0}",
+		"required_unless":    "is required depending on {0}",
+		"required_with":      "is required when {params} is present",
+		"required_without":   "is required when {params} is missing",
+		"email":              "must be a valid email address",
+		"url":                "must be a valid URL",
+		"alpha":              "must contain only letters",
+		"alphanum":           "must contain only letters and numbers",
+		"numeric":            "must contain only numbers",
+		"int":                "must be an integer",
+		"float":              "must be a number",
+		"uuid":               "must be a valid UUID",
+		"length":             "must be between {0} and {1} characters long",
+		"stringlength":       "must be between {0} and {1} characters long",
+		"minstringlength":    "must be at least {0} characters long",
+		"maxstringlength":    "must be at most {0} characters long",
+		"range":              "must be between {0} and {1}",
+		"in":                 "must be one of {params}",
+		"minitems":           "must have at least {0} items",
+		"maxitems":           "must have at most {0} items",
+		"items":              "must have between {0} and {1} items",
+		"unique":             "must not contain duplicates",
+		"eqfield":            "must be equal to {0}",
+		"nefield":            "must not be equal to {0}",
+		"gtfield":            "must be greater than {0}",
+		"gtefield":           "must be greater than or equal to {0}",
+		"ltfield":            "must be less than {0}",
+		"ltefield":           "must be less than or equal to {0}",
+	},
+	"de": {
+		"*":                  "ist ungültig für {validator}",
+		"not_*":              "darf nicht {validator} entsprechen",
+		CodeRequired:         "ist erforderlich",
+		CodeNoValidator:      "hat keine Validierung definiert",
+		CodeInvalidValidator: "hat einen ungültigen Validator {validator}",
+		CodeUnsupportedKind:  "kann nicht mit {validator} validiert werden",
+		CodeUnknownField:     "verweist auf das unbekannte Feld {0}",
+		CodeIncomparable:     "kann nicht mit {0} verglichen werden",
+		"required_if":        "ist abhängig von {0} erforderlich",
+		"required_unless":    "ist abhängig von {0} erforderlich",
+		"required_with":      "ist erforderlich, wenn {params} angegeben ist",
+		"required_without":   "ist erforderlich, wenn {params} fehlt",
+		"email":              "muss eine gültige E-Mail-Adresse sein",
+		"url":                "muss eine gültige URL sein",
+		"alpha":              "darf nur Buchstaben enthalten",
+		"alphanum":           "darf nur Buchstaben und Ziffern enthalten",
+		"numeric":            "darf nur Ziffern enthalten",
+		"int":                "muss eine ganze Zahl sein",
+		"float":              "muss eine Zahl sein",
+		"uuid":               "muss eine gültige UUID sein",
+		"length":             "muss zwischen {0} und {1} Zeichen lang sein",
+		"stringlength":       "muss zwischen {0} und {1} Zeichen lang sein",
+		"minstringlength":    "muss mindestens {0} Zeichen lang sein",
+		"maxstringlength":    "darf höchstens {0} Zeichen lang sein",
+		"range":              "muss zwischen {0} und {1} liegen",
+		"in":                 "muss einer der Werte {params} sein",
+		"minitems":           "muss mindestens {0} Einträge enthalten",
+		"maxitems":           "darf höchstens {0} Einträge enthalten",
+		"items":              "muss zwischen {0} und {1} Einträge enthalten",
+		"unique":             "darf keine Duplikate enthalten",
+		"eqfield":            "muss gleich {0} sein",
+		"nefield":            "darf nicht gleich {0} sein",
+		"gtfield":            "muss größer als {0} sein",
+		"gtefield":           "muss größer oder gleich {0} sein",
+		"ltfield":            "muss kleiner als {0} sein",
+		"ltefield":           "muss kleiner oder gleich {0} sein",
+	},
+	"fr": {
+		"*":                  "n'est pas valide pour {validator}",
+		"not_*":              "ne doit pas être valide pour {validator}",
+		CodeRequired:         "est obligatoire",
+		CodeNoValidator:      "n'a aucune validation définie",
+		CodeInvalidValidator: "a un validateur invalide {validator}",
+		CodeUnsupportedKind:  "ne peut pas être validé par {validator}",
+		CodeUnknownField:     "fait référence au champ inconnu {0}",
+		CodeIncomparable:     "ne peut pas être comparé à {0}",
+		"required_if":        "est obligatoire selon {0}",
+		"required_unless":    "est obligatoire selon {0}",
+		"required_with":      "est obligatoire lorsque {params} est renseigné",
+		"required_without":   "est obligatoire lorsque {params} est absent",
+		"email":              "doit être une adresse e-mail valide",
+		"url":                "doit être une URL valide",
+		"alpha":              "ne doit contenir que des lettres",
+		"alphanum":           "ne doit contenir que des lettres et des chiffres",
+		"numeric":            "ne doit contenir que des chiffres",
+		"int":                "doit être un nombre entier",
+		"float":              "doit être un nombre",
+		"uuid":               "doit être un UUID valide",
+		"length":             "doit contenir entre {0} et {1} caractères",
+		"stringlength":       "doit contenir entre {0} et {1} caractères",
+		"minstringlength":    "doit contenir au moins {0} caractères",
+		"maxstringlength":    "doit contenir au plus {0} caractères",
+		"range":              "doit être compris entre {0} et {1}",
+		"in":                 "doit être l'une des valeurs {params}",
+		"minitems":           "doit contenir au moins {0} éléments",
+		"maxitems":           "doit contenir au plus {0} éléments",
+		"items":              "doit contenir entre {0} et {1} éléments",
+		"unique":             "ne doit pas contenir de doublons",
+		"eqfield":            "doit être égal à {0}",
+		"nefield":            "doit être différent de {0}",
+		"gtfield":            "doit être supérieur à {0}",
+		"gtefield":           "doit être supérieur ou égal à {0}",
+		"ltfield":            "doit être inférieur à {0}",
+		"ltefield":           "doit être inférieur ou égal à {0}",
+	},
+	"es": {
+		"*":                  "no es válido para {validator}",
+		"not_*":              "no debe ser válido para {validator}",
+		CodeRequired:         "es obligatorio",
+		CodeNoValidator:      "no tiene ninguna validación definida",
+		CodeInvalidValidator: "tiene un validador no válido {validator}",
+		CodeUnsupportedKind:  "no se puede validar con {validator}",
+		CodeUnknownField:     "hace referencia al campo desconocido {0}",
+		CodeIncomparable:     "no se puede comparar con {0}",
+		"required_if":        "es obligatorio según {0}",
+		"required_unless":    "es obligatorio según {0}",
+		"required_with":      "es obligatorio cuando {params} está presente",
+		"required_without":   "es obligatorio cuando falta {params}",
+		"email":              "debe ser una dirección de correo electrónico válida",
+		"url":                "debe ser una URL válida",
+		"alpha":              "solo puede contener letras",
+		"alphanum":           "solo puede contener letras y números",
+		"numeric":            "solo puede contener números",
+		"int":                "debe ser un número entero",
+		"float":              "debe ser un número",
+		"uuid":               "debe ser un UUID válido",
+		"length":             "debe tener entre {0} y {1} caracteres",
+		"stringlength":       "debe tener entre {0} y {1} caracteres",
+		"minstringlength":    "debe tener al menos {0} caracteres",
+		"maxstringlength":    "debe tener como máximo {0} caracteres",
+		"range":              "debe estar entre {0} y {1}",
+		"in":                 "debe ser uno de los valores {params}",
+		"minitems":           "debe tener al menos {0} elementos",
+		"maxitems":           "debe tener como máximo {0} elementos",
+		"items":              "debe tener entre {0} y {1} elementos",
+		"unique":             "no debe contener duplicados",
+		"eqfield":            "debe ser igual a {0}",
+		"nefield":            "debe ser distinto de {0}",
+		"gtfield":            "debe ser mayor que {0}",
+		"gtefield":           "debe ser mayor o igual que {0}",
+		"ltfield":            "debe ser menor que {0}",
+		"ltefield":           "debe ser menor o igual que {0}",
+	},
+}
+
+type localeContextKey struct{}
+
+// ContextWithLocale returns a copy of ctx selecting the locale of the messages of
+// ValidateStructCtx and ValidateMapCtx, overriding the locale set with SetLocale.
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale set with ContextWithLocale.
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeContextKey{}).(string)
+	return locale, ok
+}
+
+// SetTranslator sets the Translator used for the messages of the package-level validation
+// functions, e.g. SetTranslator(DefaultCatalogs). Messages are only translated when a locale
+// is selected with SetLocale or ContextWithLocale.
+func SetTranslator(t Translator) {
+	defaultValidator.translator = t
+}
+
+// SetLocale sets the locale of the messages of the package-level validation functions.
+func SetLocale(locale string) {
+	defaultValidator.locale = locale
+}
+
+// translateErrors replaces the messages of err with their translation in the locale of ctx.
+func (sv *StructValidator) translateErrors(ctx context.Context, err error) error {
+	if err == nil || sv.translator == nil {
+		return err
+	}
+	locale, ok := LocaleFromContext(ctx)
+	if !ok {
+		locale = sv.locale
+	}
+	if locale == "" {
+		return err
+	}
+	return translateErrors(sv.translator, locale, err)
+}
+
+func translateErrors(t Translator, locale string, err error) error {
+	switch err2 := err.(type) {
+	case Error:
+		if message, ok := t.Translate(locale, err2); ok {
+			// the message serialized by MarshalJSON is translated without the value
+			redacted := err2
+			redacted.Value = nil
+			if without, ok := t.Translate(locale, redacted); ok && without != message {
+				err2.Err = valueMessage{message: message, redacted: without}
+			} else {
+				err2.Err = errors.New(message)
+			}
+		}
+		return err2
+	case Errors:
+		for i, err3 := range err2 {
+			err2[i] = translateErrors(t, locale, err3)
+		}
+		return err2
+	}
+	return err
+}
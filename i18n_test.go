@@ -0,0 +1,95 @@
+package govalidator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestCatalogsTranslate(t *testing.T) {
+	t.Parallel()
+
+	catalogs := Catalogs{
+		"de": {
+			"*":             "ist ungültig",
+			"not_*":         "darf nicht {validator} sein",
+			"stringlength":  "{field} muss zwischen {0} und {1} Zeichen lang sein, nicht {value}",
+			"Name is blank": "Name fehlt",
+		},
+	}
+
+	var tests = []struct {
+		locale   string
+		param    Error
+		expected string
+		ok       bool
+	}{
+		{"de", Error{Name: "name", Code: "stringlength", Params: []string{"8", "64"}, Value: "abc"}, "name muss zwischen 8 und 64 Zeichen lang sein, nicht abc", true},
+		{"de-AT", Error{Name: "name", Code: "email"}, "ist ungültig", true},
+		{"de", Error{Name: "name", Validator: "!email", Code: "not_email"}, "darf nicht email sein", true},
+		{"de", Error{Name: "name", Err: fmt.Errorf("Name is blank"), CustomErrorMessageExists: true}, "Name fehlt", true},
+		{"de", Error{Name: "name", Err: fmt.Errorf("Untranslated"), CustomErrorMessageExists: true}, "", false},
+		{"it", Error{Name: "name", Code: "email"}, "", false},
+	}
+	for _, test := range tests {
+		actual, ok := catalogs.Translate(test.locale, test.param)
+		if actual != test.expected || ok != test.ok {
+			t.Errorf("Expected Translate(%q, %v) to be %q, %v, got %q, %v", test.locale, test.param.Code, test.expected, test.ok, actual, ok)
+		}
+	}
+}
+
+func TestValidateStructLocale(t *testing.T) {
+	t.Parallel()
+
+	type Login struct {
+		Email    string `valid:"email,required"`
+		Password string `valid:"stringlength(8|64)"`
+	}
+	login := Login{Email: "nope", Password: "short"}
+
+	v := New(WithTranslator(DefaultCatalogs))
+	if _, err := v.ValidateStruct(login); err.Error() != "Email: nope does not validate as email;Password: short does not validate as stringlength(8|64)" {
+		t.Errorf("Expected messages not to be translated without a locale, got %v", err)
+	}
+
+	_, err := v.ValidateStructCtx(ContextWithLocale(context.Background(), "fr"), login)
+	if expected := "Email: doit être une adresse e-mail valide;Password: doit contenir entre 8 et 64 caractères"; err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+
+	v = New(WithTranslator(DefaultCatalogs), WithLocale("de"))
+	_, err = v.ValidateStruct(Login{Password: "long enough"})
+	if expected := "Email: ist erforderlich"; err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+	_, err = v.ValidateStructCtx(ContextWithLocale(context.Background(), "es"), Login{Password: "long enough"})
+	if expected := "Email: es obligatorio"; err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+
+	type Paint struct {
+		Color string `valid:"in(red|green|blue)"`
+	}
+	v = New(WithTranslator(DefaultCatalogs), WithLocale("en"))
+	_, err = v.ValidateStruct(Paint{Color: "pink"})
+	if expected := "Color: must be one of red|green|blue"; err == nil || err.Error() != expected {
+		t.Errorf("Expected %q, got %v", expected, err)
+	}
+	err = ValidateWith(context.Background(), v, "pink", OneOf("red", "green", "blue"))
+	if expected := "must be one of red, green, blue"; err == nil || err.Error() != expected {
+		t.Errorf("Expected %q, got %v", expected, err)
+	}
+}
+
+func TestValidateMapLocale(t *testing.T) {
+	t.Parallel()
+
+	v := New(WithTranslator(DefaultCatalogs), WithLocale("de"))
+	_, err := v.ValidateMap(map[string]interface{}{"user": map[string]interface{}{"email": "nope"}}, map[string]interface{}{
+		"user": map[string]interface{}{"email": "email"},
+	})
+	if expected := "email: muss eine gültige E-Mail-Adresse sein"; err == nil || ErrorByField(err, "email") != "muss eine gültige E-Mail-Adresse sein" {
+		t.Errorf("Expected %q, got %v", expected, err)
+	}
+}
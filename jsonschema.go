@@ -0,0 +1,247 @@
+package govalidator
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDialect is the JSON Schema version of the documents returned by JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is a JSON Schema document, or a schema nested in one.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Minimum              json.Number            `json:"minimum,omitempty"`
+	Maximum              json.Number            `json:"maximum,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	PropertyNames        *jsonSchema            `json:"propertyNames,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// jsonSchemaFormats maps the validators of TagMap to the JSON Schema formats they check.
+var jsonSchemaFormats = map[string]string{
+	"email":   "email",
+	"url":     "uri",
+	"requrl":  "uri",
+	"uuid":    "uuid",
+	"uuidv3":  "uuid",
+	"uuidv4":  "uuid",
+	"uuidv5":  "uuid",
+	"ipv4":    "ipv4",
+	"ipv6":    "ipv6",
+	"dns":     "hostname",
+	"rfc3339": "date-time",
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// JSONSchema returns a JSON Schema describing the JSON encoding of a struct type, given as a
+// reflect.Type or a sample value, with the rules of its `valid` tags that JSON Schema can express:
+// email, url, uuid and the address validators as formats, stringlength, runelength,
+// minstringlength and maxstringlength as minLength and maxLength, range as minimum and maximum,
+// in as enum, matches as pattern and required as required, along with a minLength of 1 for
+// strings. Properties are named after the json
+// tags like in errors. Nested struct types are described in $defs.
+//
+// The other validators and the negated ones are left out, so the schema accepts values that
+// ValidateStruct rejects. Note that matches patterns are written as they are, while JSON Schema
+// uses the ECMA-262 syntax.
+func JSONSchema(sample interface{}) ([]byte, error) {
+	return defaultValidator.JSONSchema(sample)
+}
+
+// JSONSchema returns the JSON Schema of a struct type with the tags, validators and policies of
+// this instance, see JSONSchema.
+func (sv *StructValidator) JSONSchema(sample interface{}) ([]byte, error) {
+	t, err := structType(sample)
+	if err != nil {
+		return nil, err
+	}
+	b := &schemaBuilder{sv: sv, names: map[reflect.Type]string{t: ""}, defs: map[string]*jsonSchema{}}
+	s := b.structSchema(t)
+	s.Schema = JSONSchemaDialect
+	if len(b.defs) > 0 {
+		s.Defs = b.defs
+	}
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// schemaBuilder builds the schema of a struct type and of the named struct types it contains.
+type schemaBuilder struct {
+	sv *StructValidator
+	// names are the names of the struct types in $defs, "" for the root type
+	names map[reflect.Type]string
+	defs  map[string]*jsonSchema
+}
+
+// structSchema describes the fields of the struct type t that are validated by ValidateStruct,
+// except the unexported ones and the ones left out of JSON with a json:"-" tag.
+func (b *schemaBuilder) structSchema(t reflect.Type) *jsonSchema {
+	s := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}}
+	for _, f := range b.sv.structPlan(t, "").fields {
+		typeField := t.FieldByIndex(f.index)
+		if f.embedded || f.unexported || typeField.Tag.Get("json") == "-" {
+			// encoding/json doesn't write unexported fields, see SetUnexportedFields
+			continue
+		}
+		name := f.pathName()
+		var p *tagPlan
+		if f.tag != "-" {
+			p = f.tagPlan
+		}
+		s.Properties[name] = b.schema(typeField.Type, p)
+		if p == nil {
+			continue
+		}
+		_, required := p.options["required"]
+		_, optional := p.options["optional"]
+		if required || (b.sv.fieldsRequiredByDefault && !optional) {
+			s.Required = append(s.Required, name)
+			if prop := s.Properties[name]; typeField.Type.Kind() == reflect.String && (prop.MinLength == nil || *prop.MinLength < 1) {
+				// required rejects empty strings too
+				one := 1
+				prop.MinLength = &one
+			}
+		}
+	}
+	return s
+}
+
+// schema describes the values of type t validated by the plan p, which is nil when they are not
+// validated. Like ValidateStruct, the options apply to the elements of collections unless the
+// tag dives into them.
+func (b *schemaBuilder) schema(t reflect.Type, p *tagPlan) *jsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	elements := p
+	if p != nil && p.dive != nil {
+		elements = p.dive
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			// encoding/json writes []byte as a base64 string
+			return &jsonSchema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &jsonSchema{Type: "array", Items: b.schema(t.Elem(), elements)}
+	case reflect.Map:
+		s := &jsonSchema{Type: "object", AdditionalProperties: b.schema(t.Elem(), elements)}
+		if p != nil && p.keys != nil {
+			s.PropertyNames = b.schema(t.Key(), p.keys)
+		}
+		return s
+	case reflect.Struct:
+		if t == timeType {
+			return &jsonSchema{Type: "string", Format: "date-time"}
+		}
+		if reflect.PtrTo(t).Implements(textMarshalerType) {
+			return &jsonSchema{Type: "string"}
+		}
+		return b.structRef(t)
+	case reflect.String:
+		return b.applyValidators(&jsonSchema{Type: "string"}, p)
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return b.applyValidators(&jsonSchema{Type: "integer"}, p)
+	case reflect.Float32, reflect.Float64:
+		return b.applyValidators(&jsonSchema{Type: "number"}, p)
+	}
+	// interfaces and the kinds encoding/json doesn't support are not described
+	return &jsonSchema{}
+}
+
+// structRef returns a reference to the schema of the named struct type t in $defs, adding it on
+// first use. Anonymous struct types are described in place.
+func (b *schemaBuilder) structRef(t reflect.Type) *jsonSchema {
+	if t.Name() == "" {
+		return b.structSchema(t)
+	}
+	name, ok := b.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := b.defs[name]; taken {
+			// a type of another package has the same name
+			name = strings.ReplaceAll(t.String(), ".", "_")
+		}
+		b.names[t] = name
+		// reserves the name while the fields are described
+		b.defs[name] = nil
+		b.defs[name] = b.structSchema(t)
+	}
+	if name == "" {
+		return &jsonSchema{Ref: "#"}
+	}
+	return &jsonSchema{Ref: "#/$defs/" + name}
+}
+
+// applyValidators adds to s the keywords checking what the validators of p check.
+func (b *schemaBuilder) applyValidators(s *jsonSchema, p *tagPlan) *jsonSchema {
+	if p == nil {
+		return s
+	}
+	for i := range p.validators {
+		vp := &p.validators[i]
+		if vp.negate {
+			continue
+		}
+		switch vp.kind {
+		case tagValidator:
+			if format, ok := jsonSchemaFormats[vp.key]; ok {
+				s.Format = format
+			}
+		case paramValidator:
+			switch vp.key {
+			case "stringlength", "runelength":
+				s.MinLength, s.MaxLength = atoiPtr(vp.params[0]), atoiPtr(vp.params[1])
+			case "minstringlength":
+				s.MinLength = atoiPtr(vp.params[0])
+			case "maxstringlength":
+				s.MaxLength = atoiPtr(vp.params[0])
+			case "range":
+				s.Minimum, s.Maximum = json.Number(vp.params[0]), json.Number(vp.params[1])
+			case "in":
+				s.Enum = enumValues(s.Type, strings.Split(vp.params[0], "|"))
+			case "matches":
+				s.Pattern = vp.params[0]
+			}
+		}
+	}
+	return s
+}
+
+func atoiPtr(s string) *int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// enumValues returns the values of an in validator, as numbers for the number types.
+func enumValues(typ string, values []string) []interface{} {
+	enum := make([]interface{}, len(values))
+	for i, v := range values {
+		enum[i] = v
+		if _, err := strconv.ParseFloat(v, 64); err == nil && typ != "string" {
+			enum[i] = json.Number(v)
+		}
+	}
+	return enum
+}
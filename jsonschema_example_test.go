@@ -0,0 +1,51 @@
+package govalidator
+
+import "fmt"
+
+func ExampleJSONSchema() {
+	type Signup struct {
+		Email string `json:"email" valid:"required,email"`
+		Name  string `json:"name" valid:"stringlength(2|40)"`
+		Plan  string `json:"plan" valid:"in(free|pro)"`
+		Age   int    `json:"age" valid:"range(18|130)"`
+	}
+
+	schema, err := JSONSchema(Signup{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(string(schema))
+	// Output:
+	// {
+	//   "$schema": "https://json-schema.org/draft/2020-12/schema",
+	//   "type": "object",
+	//   "properties": {
+	//     "age": {
+	//       "type": "integer",
+	//       "minimum": 18,
+	//       "maximum": 130
+	//     },
+	//     "email": {
+	//       "type": "string",
+	//       "format": "email",
+	//       "minLength": 1
+	//     },
+	//     "name": {
+	//       "type": "string",
+	//       "minLength": 2,
+	//       "maxLength": 40
+	//     },
+	//     "plan": {
+	//       "type": "string",
+	//       "enum": [
+	//         "free",
+	//         "pro"
+	//       ]
+	//     }
+	//   },
+	//   "required": [
+	//     "email"
+	//   ]
+	// }
+}
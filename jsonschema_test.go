@@ -0,0 +1,186 @@
+package govalidator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	Street string `json:"street" valid:"required"`
+	Zip    string `json:"zip" valid:"matches(^[0-9]{5}$)"`
+}
+
+type schemaNode struct {
+	Name     string       `json:"name" valid:"runelength(1|10)"`
+	Children []schemaNode `json:"children"`
+}
+
+type schemaBase struct {
+	ID string `json:"id" valid:"uuidv4,required"`
+}
+
+type schemaOrder struct {
+	schemaBase
+	Email    string            `json:"email" valid:"email,required"`
+	Site     *string           `valid:"url"`
+	Code     string            `json:"code" valid:"!numeric,maxstringlength(8)"`
+	Count    uint              `json:"count" valid:"in(1|2|3)"`
+	Ratio    float64           `json:"ratio" valid:"range(0|1)"`
+	Paid     bool              `json:"paid"`
+	Tags     []string          `json:"tags" valid:"alpha,stringlength(1|5)"`
+	Labels   map[string]string `json:"labels" valid:"dive,keys,in(a|b),endkeys,email"`
+	Home     schemaAddress     `json:"home" valid:"required"`
+	Work     *schemaAddress    `json:"work"`
+	Tree     schemaNode        `json:"tree"`
+	Created  time.Time         `json:"created"`
+	Avatar   []byte            `json:"avatar"`
+	Any      interface{}       `json:"any"`
+	Skipped  string            `json:"skipped" valid:"-"`
+	Hidden   string            `json:"-" valid:"email"`
+	internal string
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	data, err := JSONSchema(&schemaOrder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"id": {"type": "string", "format": "uuid", "minLength": 1},
+			"email": {"type": "string", "format": "email", "minLength": 1},
+			"Site": {"type": "string", "format": "uri"},
+			"code": {"type": "string", "maxLength": 8},
+			"count": {"type": "integer", "enum": [1, 2, 3]},
+			"ratio": {"type": "number", "minimum": 0, "maximum": 1},
+			"paid": {"type": "boolean"},
+			"tags": {"type": "array", "items": {"type": "string", "minLength": 1, "maxLength": 5}},
+			"labels": {
+				"type": "object",
+				"propertyNames": {"type": "string", "enum": ["a", "b"]},
+				"additionalProperties": {"type": "string", "format": "email"}
+			},
+			"home": {"$ref": "#/$defs/schemaAddress"},
+			"work": {"$ref": "#/$defs/schemaAddress"},
+			"tree": {"$ref": "#/$defs/schemaNode"},
+			"created": {"type": "string", "format": "date-time"},
+			"avatar": {"type": "string", "contentEncoding": "base64"},
+			"any": {},
+			"skipped": {"type": "string"}
+		},
+		"required": ["id", "email", "home"],
+		"$defs": {
+			"schemaAddress": {
+				"type": "object",
+				"properties": {
+					"street": {"type": "string", "minLength": 1},
+					"zip": {"type": "string", "pattern": "^[0-9]{5}$"}
+				},
+				"required": ["street"]
+			},
+			"schemaNode": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "minLength": 1, "maxLength": 10},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/schemaNode"}}
+				}
+			}
+		}
+	}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSONSchema() = %s", data)
+	}
+}
+
+func TestJSONSchemaRecursiveRoot(t *testing.T) {
+	t.Parallel()
+
+	data, err := JSONSchema(reflect.TypeOf(schemaNode{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Properties map[string]struct {
+			Items struct {
+				Ref string `json:"$ref"`
+			} `json:"items"`
+		} `json:"properties"`
+		Defs map[string]interface{} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if ref := got.Properties["children"].Items.Ref; ref != "#" || got.Defs != nil {
+		t.Errorf("JSONSchema() = %s, want children referencing the root", data)
+	}
+}
+
+func TestJSONSchemaRequiredByDefault(t *testing.T) {
+	t.Parallel()
+
+	type Signup struct {
+		Email string `json:"email" valid:"email"`
+		Name  string `json:"name" valid:"optional"`
+		Note  string `json:"note"`
+	}
+	data, err := New(WithFieldsRequiredByDefault(true)).JSONSchema(Signup{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got jsonSchema
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Required, []string{"email", "note"}) {
+		t.Errorf("required = %v, want [email note]", got.Required)
+	}
+	for name, minLength := range map[string]*int{"email": atoiPtr("1"), "name": nil, "note": atoiPtr("1")} {
+		if actual := got.Properties[name].MinLength; !reflect.DeepEqual(actual, minLength) {
+			t.Errorf("%s minLength = %v, want %v", name, actual, minLength)
+		}
+	}
+}
+
+func TestJSONSchemaPatternAndUnexported(t *testing.T) {
+	t.Parallel()
+
+	type Device struct {
+		Mode   string `json:"mode" valid:"matches(^r$|^w$)"`
+		secret string `valid:"required"`
+	}
+	data, err := New(WithUnexportedFields(true)).JSONSchema(Device{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got jsonSchema
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if pattern := got.Properties["mode"].Pattern; pattern != "^r$|^w$" {
+		t.Errorf("pattern = %q, want ^r$|^w$", pattern)
+	}
+	if _, ok := got.Properties["secret"]; ok || got.Required != nil {
+		t.Errorf("JSONSchema() = %s, want the unexported field left out", data)
+	}
+}
+
+func TestJSONSchemaNotStruct(t *testing.T) {
+	t.Parallel()
+
+	if _, err := JSONSchema("text"); err == nil {
+		t.Error("JSONSchema(string) succeeded, want an error")
+	}
+}
@@ -0,0 +1,153 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CheckStructTags checks the tags of a struct type, given as a reflect.Type or a sample value, and of
+// the structs it contains against the package-level validators, each struct type being checked once.
+// It reports every option ParseTag rejects, such as unknown validators and param validators with the
+// wrong params, validators applied to a kind they don't support and dive applied to a field that is
+// not a collection. The problems are returned as Errors of Error wrapping a *TagError, with paths starting with the
+// type name. It is meant to be called from init() or a unit test, so that mistakes surface before
+// the first value is validated.
+func CheckStructTags(sample interface{}) error {
+	return defaultValidator.CheckStructTags(sample)
+}
+
+// CheckStructTags checks the tags of a struct type against the validators and policies configured on
+// sv, see CheckStructTags.
+func (sv *StructValidator) CheckStructTags(sample interface{}) error {
+	t, err := structType(sample)
+	if err != nil {
+		return err
+	}
+	var errs Errors
+	sv.checkStructTags(t, []string{t.String()}, map[reflect.Type]bool{}, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkStructTags appends to errs the problems of the fields of t, then checks the structs they contain.
+func (sv *StructValidator) checkStructTags(t reflect.Type, path []string, visited map[reflect.Type]bool, errs *Errors) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	for _, f := range sv.structPlan(t, "").fields {
+		ft := t.FieldByIndex(f.index).Type
+		report := func(err error, validator, code string) {
+			*errs = append(*errs, Error{Name: f.name, Err: err, Validator: validator, Path: path, Field: f.name, Code: code})
+		}
+		switch f.tag {
+		case "-":
+			continue
+		case "":
+			if sv.fieldsRequiredByDefault && ft.Kind() != reflect.Slice && ft.Kind() != reflect.Map {
+				report(fmt.Errorf("All fields are required to at least have one validation defined"), "required", CodeNoValidator)
+			}
+		default:
+			options, err := sv.ParseTag(f.tag)
+			if err != nil {
+				for _, e := range err.(Errors) {
+					report(e, "", CodeInvalidValidator)
+				}
+			}
+			sv.checkKinds(ft, options, func(column int, validator, msg string) {
+				report(&TagError{column, msg}, validator, CodeUnsupportedKind)
+			})
+		}
+		if f.embedded {
+			// the fields of embedded structs are checked as fields of t
+			continue
+		}
+		if inner := elemType(ft); inner.Kind() == reflect.Struct {
+			sv.checkStructTags(inner, append(append([]string{}, path...), f.name), visited, errs)
+		}
+	}
+}
+
+// checkKinds reports the options that can't be applied to the values of type t.
+func (sv *StructValidator) checkKinds(t reflect.Type, options []TagOption, report func(column int, validator, msg string)) {
+	collection, keys, elements, dive := splitDiveOptions(options)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if dive != nil {
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+		default:
+			report(dive.Column, "dive", fmt.Sprintf("Validator dive doesn't support kind %s", t.Kind()))
+			return
+		}
+		for _, opt := range collection {
+			if vp := sv.compileValidator(opt.Spec(), ""); (vp.kind == tagValidator || vp.kind == paramValidator) && vp.key != "length" {
+				report(opt.Column, opt.Name, fmt.Sprintf("Validator %s can't be applied to a collection, only to its elements after dive", opt.Name))
+			}
+		}
+		if keys != nil {
+			if t.Kind() != reflect.Map {
+				report(dive.Column, "keys", fmt.Sprintf("Validator keys doesn't support kind %s", t.Kind()))
+			} else {
+				sv.checkKinds(t.Key(), keys, report)
+			}
+		}
+		sv.checkKinds(t.Elem(), elements, report)
+		return
+	}
+	if len(collection) == 0 {
+		return
+	}
+	// without dive, the options apply to every element of collections
+	elem := t
+	for elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array || elem.Kind() == reflect.Map || elem.Kind() == reflect.Ptr {
+		if elem.Kind() == reflect.Map && elem.Key().Kind() != reflect.String {
+			report(collection[0].Column, "", (&UnsupportedTypeError{elem}).Error())
+			return
+		}
+		elem = elem.Elem()
+	}
+	if elem.Kind() == reflect.Interface {
+		// the kind is only known at validation time
+		return
+	}
+	for _, opt := range collection {
+		vp := sv.compileValidator(opt.Spec(), "")
+		if (vp.kind == tagValidator || vp.kind == paramValidator) && !isStringableKind(elem.Kind()) {
+			report(opt.Column, opt.Name, fmt.Sprintf("Validator %s doesn't support kind %s", opt.Name, elem.Kind()))
+		}
+	}
+}
+
+// splitDiveOptions is splitDiveTag for parsed options. dive is nil when there is no dive option.
+func splitDiveOptions(options []TagOption) (collection, keys, elements []TagOption, dive *TagOption) {
+	for i := range options {
+		if options[i].Name != "dive" {
+			continue
+		}
+		rest := options[i+1:]
+		if len(rest) > 0 && rest[0].Name == "keys" {
+			for j := 1; j < len(rest); j++ {
+				if rest[j].Name == "endkeys" {
+					keys = rest[1:j]
+					rest = rest[j+1:]
+					break
+				}
+			}
+		}
+		return options[:i], keys, rest, &options[i]
+	}
+	return options, nil, nil, nil
+}
+
+// elemType returns the type of the values validated as a whole for a field of type t: the element
+// type of pointers and collections.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,21 @@
+package govalidator
+
+import "fmt"
+
+func ExampleCheckStructTags() {
+	type Signup struct {
+		Email  string `valid:"required,emial"`
+		Terms  bool   `valid:"alpha"`
+		Age    int    `valid:"range(18)"`
+		Groups int    `valid:"dive,alpha"`
+	}
+
+	for _, err := range CheckStructTags(Signup{}).(Errors) {
+		fmt.Println(err)
+	}
+	// Output:
+	// govalidator.Signup.Email: column 10: unknown validator emial
+	// govalidator.Signup.Terms: column 1: Validator alpha doesn't support kind bool
+	// govalidator.Signup.Age: column 1: malformed params for range
+	// govalidator.Signup.Groups: column 1: Validator dive doesn't support kind int
+}
@@ -0,0 +1,67 @@
+package govalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type lintAddress struct {
+	Street string `valid:"required,alpah"`
+}
+
+type lintUser struct {
+	Name     string            `valid:"required,emial"`
+	Age      int               `valid:"length(a|b)"`
+	Active   bool              `valid:"alpha"`
+	Address  lintAddress       `valid:"email"`
+	Previous *lintAddress      `valid:"-"`
+	Tags     []string          `valid:"dive,alpha"`
+	Scores   map[int]int       `valid:"numeric"`
+	Labels   map[string]string `valid:"dive,keys,alpha,endkeys,alpha"`
+	Count    int               `valid:"dive,numeric"`
+	Parent   *lintUser         `valid:"optional"`
+	Items    []lintAddress
+	Any      interface{} `valid:"alpha"`
+}
+
+func TestCheckStructTags(t *testing.T) {
+	t.Parallel()
+
+	err := CheckStructTags(&lintUser{})
+	expected := map[string]string{
+		"govalidator.lintUser.Name":    "column 10: unknown validator emial",
+		"govalidator.lintUser.Age":     "column 1: malformed params for length",
+		"govalidator.lintUser.Active":  "column 1: Validator alpha doesn't support kind bool",
+		"govalidator.lintUser.Address": "column 1: Validator email doesn't support kind struct",
+		"govalidator.lintUser.Scores":  "column 1: validator: unsupported type: map[int]int",
+		"govalidator.lintUser.Count":   "column 1: Validator dive doesn't support kind int",
+		// lintAddress is checked once, Items are not reported again
+		"govalidator.lintUser.Address.Street": "column 10: unknown validator alpah",
+	}
+	if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected CheckStructTags to report %v, got %v", expected, actual)
+	}
+	var tagErr *TagError
+	if !errors.As(err, &tagErr) || tagErr.Column != 10 {
+		t.Errorf("Expected errors.As to find the TagError, got %v", tagErr)
+	}
+
+	type Valid struct {
+		Email string            `valid:"required,email~'Please, an email'"`
+		Tags  map[string]string `valid:"length(1|3),dive,keys,alpha,endkeys,stringlength(1|5)"`
+	}
+	if err := CheckStructTags(reflect.TypeOf(Valid{})); err != nil {
+		t.Errorf("Expected the tags to be valid, got %v", err)
+	}
+	if err := CheckStructTags(1); err == nil {
+		t.Error("Expected an error for a non-struct")
+	}
+
+	type Untagged struct {
+		Email string
+	}
+	if err := New(WithFieldsRequiredByDefault(true)).CheckStructTags(Untagged{}); ErrorByField(err, "Email") == "" {
+		t.Errorf("Expected the fields without tag to be reported, got %v", err)
+	}
+}
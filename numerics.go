@@ -1,6 +1,7 @@
 package govalidator
 
 import (
+	"cmp"
 	"math"
 )
 
@@ -89,6 +90,15 @@ func InRange(value interface{}, left interface{}, right interface{}) bool {
 	}
 }
 
+// InRangeOf returns true if value lies between left and right border, like InRange but checked at
+// compile time: the three values have the same ordered type, integer, float or string.
+func InRangeOf[T cmp.Ordered](value, left, right T) bool {
+	if left > right {
+		left, right = right, left
+	}
+	return value >= left && value <= right
+}
+
 // IsWhole returns true if value is whole number
 func IsWhole(value float64) bool {
 	return math.Remainder(value, 1) == 0
@@ -60,6 +60,12 @@ func ExampleInRange() {
 	_ = InRange("abc", "a", "cba")      // true
 }
 
+func ExampleInRangeOf() {
+	_ = InRangeOf(10, 11, 20)             // false
+	_ = InRangeOf(10.02, -10.124, 10.234) // true
+	_ = InRangeOf("abc", "a", "cba")      // true
+}
+
 func ExampleIsWhole() {
 	_ = IsWhole(1.123) // false
 	_ = IsWhole(1.0)   // true
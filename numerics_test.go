@@ -134,6 +134,46 @@ func TestIsNonPositive(t *testing.T) {
 	}
 }
 
+func TestInRangeOf(t *testing.T) {
+	t.Parallel()
+
+	var testsInt = []struct {
+		param    int
+		left     int
+		right    int
+		expected bool
+	}{
+		{0, 0, 0, true},
+		{1, 0, 0, false},
+		{0, -1, 1, true},
+		{0, 0, -1, true},
+		{0, 10, 5, false},
+	}
+	for _, test := range testsInt {
+		actual := InRangeOf(test.param, test.left, test.right)
+		if actual != test.expected {
+			t.Errorf("Expected InRangeOf(%v, %v, %v) to be %v, got %v", test.param, test.left, test.right, test.expected, actual)
+		}
+	}
+
+	var testsString = []struct {
+		param    string
+		left     string
+		right    string
+		expected bool
+	}{
+		{"abc", "a", "cba", true},
+		{"cba", "abc", "a", false},
+		{"", "", "", true},
+	}
+	for _, test := range testsString {
+		actual := InRangeOf(test.param, test.left, test.right)
+		if actual != test.expected {
+			t.Errorf("Expected InRangeOf(%q, %q, %q) to be %v, got %v", test.param, test.left, test.right, test.expected, actual)
+		}
+	}
+}
+
 func TestIsWhole(t *testing.T) {
 	t.Parallel()
 
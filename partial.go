@@ -0,0 +1,102 @@
+package govalidator
+
+import (
+	"context"
+	"strings"
+)
+
+// fieldSelection is the tree of the dotted paths given to ValidateStructPartial or
+// ValidateStructExcept. A node without children selects the whole field.
+type fieldSelection struct {
+	except   bool
+	children map[string]*fieldSelection
+}
+
+type selectionContextKey struct{}
+
+// ValidateStructPartial is ValidateStruct validating only the given fields, e.g. the fields sent
+// in a PATCH request. Fields are dotted paths of struct field or JSON names like "Address.Street";
+// a path through a slice or map applies to all of its elements. Other fields, including their
+// required checks, are skipped. Validatable is only called for the structs validated as a whole.
+func ValidateStructPartial(s interface{}, fields ...string) (bool, error) {
+	return defaultValidator.ValidateStructPartial(s, fields...)
+}
+
+// ValidateStructPartialCtx is the context-aware variant of ValidateStructPartial.
+func ValidateStructPartialCtx(ctx context.Context, s interface{}, fields ...string) (bool, error) {
+	return defaultValidator.ValidateStructPartialCtx(ctx, s, fields...)
+}
+
+// ValidateStructExcept is ValidateStruct skipping the given fields, see ValidateStructPartial for the paths.
+func ValidateStructExcept(s interface{}, fields ...string) (bool, error) {
+	return defaultValidator.ValidateStructExcept(s, fields...)
+}
+
+// ValidateStructExceptCtx is the context-aware variant of ValidateStructExcept.
+func ValidateStructExceptCtx(ctx context.Context, s interface{}, fields ...string) (bool, error) {
+	return defaultValidator.ValidateStructExceptCtx(ctx, s, fields...)
+}
+
+// ValidateStructPartial validates only the given fields of s, see the package-level ValidateStructPartial.
+func (sv *StructValidator) ValidateStructPartial(s interface{}, fields ...string) (bool, error) {
+	return sv.ValidateStructPartialCtx(context.Background(), s, fields...)
+}
+
+// ValidateStructPartialCtx is the context-aware variant of ValidateStructPartial.
+func (sv *StructValidator) ValidateStructPartialCtx(ctx context.Context, s interface{}, fields ...string) (bool, error) {
+	return sv.ValidateStructCtx(context.WithValue(ctx, selectionContextKey{}, newFieldSelection(fields, false)), s)
+}
+
+// ValidateStructExcept validates s skipping the given fields, see the package-level ValidateStructExcept.
+func (sv *StructValidator) ValidateStructExcept(s interface{}, fields ...string) (bool, error) {
+	return sv.ValidateStructExceptCtx(context.Background(), s, fields...)
+}
+
+// ValidateStructExceptCtx is the context-aware variant of ValidateStructExcept.
+func (sv *StructValidator) ValidateStructExceptCtx(ctx context.Context, s interface{}, fields ...string) (bool, error) {
+	return sv.ValidateStructCtx(context.WithValue(ctx, selectionContextKey{}, newFieldSelection(fields, true)), s)
+}
+
+func newFieldSelection(fields []string, except bool) *fieldSelection {
+	root := &fieldSelection{except: except, children: map[string]*fieldSelection{}}
+	for _, field := range fields {
+		node := root
+		for _, name := range strings.Split(field, ".") {
+			if node.children == nil {
+				// a parent path was given, which selects the whole field already
+				break
+			}
+			child, ok := node.children[name]
+			if !ok {
+				child = &fieldSelection{except: except, children: map[string]*fieldSelection{}}
+				node.children[name] = child
+			}
+			node = child
+		}
+		// the last field is selected as a whole
+		node.children = nil
+	}
+	return root
+}
+
+func selectionFromContext(ctx context.Context) *fieldSelection {
+	sel, _ := ctx.Value(selectionContextKey{}).(*fieldSelection)
+	return sel
+}
+
+// selectField returns the context to validate the field f with, and whether the field and
+// its own tag options are validated at all.
+func (sel *fieldSelection) selectField(ctx context.Context, f *fieldPlan) (fieldCtx context.Context, skip bool, ownOptions bool) {
+	child, ok := sel.children[f.name]
+	if !ok && f.jsonName != "" {
+		child, ok = sel.children[f.jsonName]
+	}
+	switch {
+	case !ok:
+		return context.WithValue(ctx, selectionContextKey{}, (*fieldSelection)(nil)), !sel.except, true
+	case child.children == nil:
+		return context.WithValue(ctx, selectionContextKey{}, (*fieldSelection)(nil)), sel.except, true
+	}
+	// only some nested fields are selected or skipped
+	return context.WithValue(ctx, selectionContextKey{}, child), false, sel.except
+}
@@ -0,0 +1,71 @@
+package govalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type partialAddress struct {
+	Street string `valid:"required" json:"street"`
+	City   string `valid:"required,alpha" json:"city"`
+}
+
+type partialUser struct {
+	Name      string           `valid:"required" json:"name"`
+	Email     string           `valid:"required,email" json:"email"`
+	Address   partialAddress   `valid:"required" json:"address"`
+	Addresses []partialAddress `valid:"required" json:"addresses"`
+}
+
+func TestValidateStructPartial(t *testing.T) {
+	t.Parallel()
+
+	user := partialUser{Email: "nope", Addresses: []partialAddress{{Street: "Main", City: "1"}}}
+
+	var tests = []struct {
+		fields   []string
+		expected map[string]string
+	}{
+		{nil, map[string]string{}},
+		{[]string{"Name"}, map[string]string{"name": "non zero value required"}},
+		{[]string{"email"}, map[string]string{"email": "nope does not validate as email"}},
+		{[]string{"Address.Street"}, map[string]string{"address.street": "non zero value required"}},
+		{[]string{"Address.Street", "Address"}, map[string]string{
+			"address.street": "non zero value required",
+			"address.city":   "non zero value required",
+			"address":        "non zero value required",
+		}},
+		{[]string{"addresses.city"}, map[string]string{"addresses.0.city": "1 does not validate as alpha"}},
+		{[]string{"Addresses.Street"}, map[string]string{}},
+		{[]string{"Unknown"}, map[string]string{}},
+	}
+	for _, test := range tests {
+		_, err := ValidateStructPartial(user, test.fields...)
+		if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected ValidateStructPartial(%v) to report %v, got %v", test.fields, test.expected, actual)
+		}
+	}
+}
+
+func TestValidateStructExcept(t *testing.T) {
+	t.Parallel()
+
+	user := partialUser{Email: "nope", Address: partialAddress{Street: "Main"}, Addresses: []partialAddress{{Street: "Main", City: "1"}}}
+
+	var tests = []struct {
+		fields   []string
+		expected map[string]string
+	}{
+		{[]string{"Name", "email", "Address", "Addresses"}, map[string]string{}},
+		{[]string{"Name", "email", "Address.City", "Addresses.city"}, map[string]string{}},
+		{[]string{"Name", "Address", "Addresses"}, map[string]string{"email": "nope does not validate as email"}},
+		{[]string{"Name", "email", "Addresses"}, map[string]string{"address.city": "non zero value required"}},
+		{[]string{"Name", "email", "Address"}, map[string]string{"addresses.0.city": "1 does not validate as alpha"}},
+	}
+	for _, test := range tests {
+		_, err := ValidateStructExcept(user, test.fields...)
+		if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected ValidateStructExcept(%v) to report %v, got %v", test.fields, test.expected, actual)
+		}
+	}
+}
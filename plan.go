@@ -0,0 +1,485 @@
+package govalidator
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// validatorKind tells typeCheck which registry a tag option was resolved against.
+type validatorKind int
+
+const (
+	unknownValidator validatorKind = iota
+	customTypeValidator
+	interfaceParamValidator
+	crossFieldValidator
+	conditionalRequiredValidator
+	paramValidator
+	tagValidator
+)
+
+// validatorPlan is a single tag option resolved once at compile time.
+// Functions are looked up by key at validation time so replacing a registered
+// validator takes effect without recompiling the plan.
+type validatorPlan struct {
+	// spec is the option as written in the tag, e.g. "!length(1|10)"
+	spec string
+	// validator is spec without the negation prefix, e.g. "length(1|10)"
+	validator string
+	// name is the validator name reported in Error.Validator, e.g. "length"
+	name               string
+	negate             bool
+	customErrorMessage string
+	kind               validatorKind
+	key                string
+	params             []string
+}
+
+// code returns the Error.Code reported when the validator fails.
+func (vp *validatorPlan) code() string {
+	if vp.negate {
+		return "not_" + strings.TrimPrefix(vp.name, "!")
+	}
+	return vp.name
+}
+
+// errorParams returns a copy of the params for Error.Params, so that changing the
+// params of an error doesn't change the cached plan.
+func (vp *validatorPlan) errorParams() []string {
+	return append([]string(nil), vp.params...)
+}
+
+// tagPlan is a parsed and resolved `valid` tag.
+type tagPlan struct {
+	tag        string
+	options    tagOptionsMap
+	validators []validatorPlan
+
+	// dive holds the options applied to every element of a collection, keys the
+	// options applied to map keys; both are nil when the tag has no dive option.
+	dive *tagPlan
+	keys *tagPlan
+}
+
+// fieldPlan is a tagPlan bound to a struct field or a map key.
+type fieldPlan struct {
+	*tagPlan
+	// index is the index sequence of the field, with several items for the fields of
+	// embedded structs, see reflect.Value.FieldByIndex
+	index    []int
+	name     string
+	jsonName string
+
+	// embedded is set for an embedded struct whose fields are flattened into the parent
+	embedded   bool
+	unexported bool
+
+	// elementPath holds the indices or map keys of the collection element validated by the plan,
+	// e.g. ["1"] for the element 1 of Tags; it is empty for the field itself
+	elementPath []string
+}
+
+// pathName returns the name of the field in error paths: its JSON name when it has one.
+func (f *fieldPlan) pathName() string {
+	if f.jsonName != "" {
+		return f.jsonName
+	}
+	return f.name
+}
+
+// element returns the plan of the element idx of the collection validated by f. Its errors
+// keep the name of the field, the index being added to their path by elementErrors.
+func (f *fieldPlan) element(idx string, plan *tagPlan) *fieldPlan {
+	return &fieldPlan{tagPlan: plan, index: f.index, name: f.name, jsonName: f.jsonName,
+		elementPath: append(append([]string{}, f.elementPath...), idx)}
+}
+
+// path returns the path of the value validated by f in errors, e.g. ["tags", "1"] for an element.
+func (f *fieldPlan) path(idx ...string) []string {
+	return append(append([]string{f.pathName()}, f.elementPath...), idx...)
+}
+
+// structPlan lists the exported fields of a struct type in declaration order,
+// followed by the fields of embedded structs in place of the embedded struct.
+type structPlan struct {
+	fields []fieldPlan
+
+	// validatable and validatableCtx tell whether the pointer type implements
+	// Validatable and ValidatableCtx
+	validatable    bool
+	validatableCtx bool
+}
+
+// planCache holds compiled plans. It is dropped as a whole when a validator is
+// registered through AddValidator and the other registration functions, or when
+// the number of entries of the registries changes, so tags that were unknown at
+// compile time are resolved once they get registered.
+type planCache struct {
+	registry registryVersion
+	structs  sync.Map // planKey -> *structPlan
+	tags     sync.Map // planKey -> *tagPlan
+}
+
+// registryVersion identifies the state of the registries a planCache was compiled against.
+// Writing to the exported maps directly only changes size, so replacing a validator that
+// way is not seen once the plans are cached.
+type registryVersion struct {
+	registrations int64
+	size          int
+}
+
+// planKey identifies a plan compiled for the active validation groups. unexported tells
+// whether the struct plan lists the unexported fields, see SetUnexportedFields.
+type planKey struct {
+	typ        reflect.Type
+	tag        string
+	groups     string
+	unexported bool
+}
+
+func (sv *StructValidator) registryVersion() registryVersion {
+	return registryVersion{
+		registrations: sv.registrations.Load() + sv.customTypeTagMap.Registrations() + sv.structRules.Registrations(),
+		size: len(sv.tagMap) + len(sv.paramTagMap) + len(sv.paramTagRegexMap) +
+			len(sv.interfaceParamTagMap) + len(sv.interfaceParamTagRegexMap) + sv.customTypeTagMap.Len(),
+	}
+}
+
+func (sv *StructValidator) plans() *planCache {
+	version := sv.registryVersion()
+	if c, ok := sv.cache.Load().(*planCache); ok && c.registry == version {
+		return c
+	}
+	c := &planCache{registry: version}
+	sv.cache.Store(c)
+	return c
+}
+
+// structPlan returns the cached plan for the struct type t and the active groups,
+// compiling it on first use.
+func (sv *StructValidator) structPlan(t reflect.Type, groups string) *structPlan {
+	c := sv.plans()
+	key := planKey{typ: t, groups: groups, unexported: sv.unexportedFields}
+	if p, ok := c.structs.Load(key); ok {
+		return p.(*structPlan)
+	}
+	p := &structPlan{
+		validatable:    reflect.PtrTo(t).Implements(validatableType),
+		validatableCtx: reflect.PtrTo(t).Implements(validatableCtxType),
+	}
+	sv.appendFields(c, p, t, nil, groups, nil, map[reflect.Type]bool{t: true})
+	actual, _ := c.structs.LoadOrStore(key, p)
+	return actual.(*structPlan)
+}
+
+// appendFields adds the fields of the struct type t to p. Like encoding/json, the fields of
+// embedded structs without a JSON name are flattened into the parent, even when the embedded
+// struct type is unexported.
+// Fields without a tag use the rules registered for t with RegisterStructRules, or for the
+// outer struct type when t is embedded, see outerRules.
+func (sv *StructValidator) appendFields(c *planCache, p *structPlan, t reflect.Type, index []int, groups string, outerRules map[string]string, visited map[reflect.Type]bool) {
+	rules := sv.structRules.Get(t)
+	for i := 0; i < t.NumField(); i++ {
+		typeField := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+		tag := typeField.Tag.Get(sv.tagName)
+		if tag == "" {
+			if rule, ok := outerRules[typeField.Name]; ok {
+				tag = rule
+			} else {
+				tag = rules[typeField.Name]
+			}
+		}
+		jsonName := toJSONName(typeField.Tag.Get("json"))
+
+		embedded := false
+		if typeField.Anonymous && tag != "-" && jsonName == "" {
+			ft := typeField.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !visited[ft] {
+				visited[ft] = true
+				sv.appendFields(c, p, ft, fieldIndex, groups, mergeRules(rules, outerRules), visited)
+				delete(visited, ft)
+				if tag == "" {
+					continue
+				}
+				// the other options apply to the embedded struct as a whole
+				embedded = true
+			}
+		}
+		if typeField.PkgPath != "" && !sv.unexportedFields {
+			continue // Private field
+		}
+		p.fields = append(p.fields, fieldPlan{
+			tagPlan:    sv.compileTag(c, tag, groups),
+			index:      fieldIndex,
+			name:       typeField.Name,
+			jsonName:   jsonName,
+			embedded:   embedded,
+			unexported: typeField.PkgPath != "",
+		})
+	}
+}
+
+// readableValue returns a copy of the value v read from an unexported field, built with the
+// accessors of its kind, e.g. String, so that it can be passed to Interface. v is returned
+// unchanged when it can already be passed to Interface, or when it holds a struct with
+// unexported fields, which can't be copied this way; such values are validated through
+// reflection only.
+// Collections are copied deeply, so it is only called where Interface is needed, see typeCheck.
+func readableValue(v reflect.Value) reflect.Value {
+	if !v.IsValid() || v.CanInterface() {
+		return v
+	}
+	if copied, ok := copyValue(v, map[uintptr]bool{}); ok {
+		return copied
+	}
+	return v
+}
+
+// copyValue copies v deeply; seen holds the pointers being copied, the values with
+// cycles aren't copied.
+func copyValue(v reflect.Value, seen map[uintptr]bool) (reflect.Value, bool) {
+	if !v.IsValid() {
+		return v, true
+	}
+	c := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Bool:
+		c.SetBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.SetInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c.SetUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		c.SetFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c.SetComplex(v.Complex())
+	case reflect.String:
+		c.SetString(v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			return c, true
+		}
+		c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := copyValue(v.Index(i), seen)
+			if !ok {
+				return v, false
+			}
+			c.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return c, true
+		}
+		c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			key, ok := copyValue(iter.Key(), seen)
+			if !ok {
+				return v, false
+			}
+			elem, ok := copyValue(iter.Value(), seen)
+			if !ok {
+				return v, false
+			}
+			c.SetMapIndex(key, elem)
+		}
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return c, true
+		}
+		if v.Kind() == reflect.Ptr {
+			if seen[v.Pointer()] {
+				return v, false
+			}
+			seen[v.Pointer()] = true
+			defer delete(seen, v.Pointer())
+		}
+		elem, ok := copyValue(v.Elem(), seen)
+		if !ok {
+			return v, false
+		}
+		if v.Kind() == reflect.Interface {
+			c.Set(elem)
+			return c, true
+		}
+		ptr := reflect.New(v.Type().Elem())
+		ptr.Elem().Set(elem)
+		c.Set(ptr)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !c.Field(i).CanSet() {
+				return v, false
+			}
+			field, ok := copyValue(v.Field(i), seen)
+			if !ok {
+				return v, false
+			}
+			c.Field(i).Set(field)
+		}
+	default:
+		// functions, channels and unsafe pointers
+		return v, false
+	}
+	return c, true
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex returning the zero value for the fields
+// of nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Zero(v.Type().Elem().FieldByIndex(index[i:]).Type)
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// tagPlan returns the cached plan for a raw tag value and the active groups, compiling it on first use.
+func (sv *StructValidator) tagPlan(tag string, groups string) *tagPlan {
+	return sv.compileTag(sv.plans(), tag, groups)
+}
+
+func (sv *StructValidator) compileTag(c *planCache, tag string, groups string) *tagPlan {
+	key := planKey{tag: tag, groups: groups}
+	if p, ok := c.tags.Load(key); ok {
+		return p.(*tagPlan)
+	}
+	p := &tagPlan{tag: tag}
+	collection, keys, elements, isDive := splitDiveTag(tag)
+	if isDive {
+		p.dive = sv.compileTag(c, elements, groups)
+		if keys != nil {
+			p.keys = sv.compileTag(c, *keys, groups)
+		}
+	}
+	collection, skipped := filterGroups(collection, groups)
+	p.options = parseTagIntoMap(collection)
+	if skipped {
+		// all the options belong to inactive groups
+		p.options["optional"] = tagOption{"optional", "", 0}
+	}
+	sv.compileValidators(p)
+	actual, _ := c.tags.LoadOrStore(key, p)
+	return actual.(*tagPlan)
+}
+
+// compileRules builds the plan of the rules given to RuleSet.Field as if they were the options of a tag.
+// The plan is kept by the RuleSet rather than cached.
+// The specs are not parsed, so their params may contain commas.
+func (sv *StructValidator) compileRules(rules []FieldRule) *tagPlan {
+	p := &tagPlan{options: tagOptionsMap{}}
+	specs := make([]string, len(rules))
+	for i, rule := range rules {
+		specs[i] = rule.spec
+		p.options[rule.spec] = tagOption{rule.spec, rule.message, i}
+	}
+	p.tag = strings.Join(specs, ",")
+	sv.compileValidators(p)
+	return p
+}
+
+// compileValidators resolves the options of p, except required, optional and redact which
+// are checked separately.
+func (sv *StructValidator) compileValidators(p *tagPlan) {
+	for _, spec := range p.options.orderedKeys() {
+		if spec == "required" || spec == "optional" || spec == "redact" {
+			continue
+		}
+		p.validators = append(p.validators, sv.compileValidator(spec, p.options[spec].customErrorMessage))
+	}
+}
+
+// takesValue reports whether some validators of p are passed the value as a whole, like custom
+// type validators and unique, rather than read through reflection.
+func (p *tagPlan) takesValue() bool {
+	for i := range p.validators {
+		if k := p.validators[i].kind; k == customTypeValidator || k == interfaceParamValidator {
+			return true
+		}
+	}
+	return false
+}
+
+// isRedacted reports whether the values of the fields validated by p are kept out of errors.
+func (sv *StructValidator) isRedacted(p *tagPlan) bool {
+	_, ok := p.options["redact"]
+	return sv.redactedValues || ok
+}
+
+// compileValidator resolves the tag option spec, as written by TagOption.Spec. Param validators
+// are passed the groups captured by their regex.
+func (sv *StructValidator) compileValidator(spec, customErrorMessage string) validatorPlan {
+	vp := validatorPlan{
+		spec:               spec,
+		validator:          spec,
+		name:               stripParams(spec),
+		customErrorMessage: customErrorMessage,
+	}
+	if _, ok := sv.customTypeTagMap.GetCtx(spec); ok {
+		vp.kind, vp.key = customTypeValidator, spec
+		return vp
+	}
+	// checks whether the tag looks like '!something' or 'something'
+	if spec[0] == '!' {
+		vp.validator = spec[1:]
+		vp.negate = true
+	}
+	if key, params, ok := matchParamTag(vp.validator, sv.interfaceParamTagRegexMap, func(key string) bool {
+		_, ok := sv.interfaceParamTagMap[key]
+		return ok
+	}); ok {
+		vp.kind, vp.key, vp.params = interfaceParamValidator, key, params
+		return vp
+	}
+	if ps := conditionalRequiredTagRegexp.FindStringSubmatch(spec); len(ps) != 0 {
+		compileConditionalRequired(&vp, ps)
+		return vp
+	}
+	if ps := crossFieldTagRegexp.FindStringSubmatch(vp.validator); len(ps) != 0 {
+		vp.kind, vp.key, vp.params = crossFieldValidator, ps[1], ps[2:]
+		return vp
+	}
+	if key, params, ok := matchParamTag(vp.validator, sv.paramTagRegexMap, func(key string) bool {
+		_, ok := sv.paramTagMap[key]
+		return ok
+	}); ok {
+		vp.kind, vp.key, vp.params = paramValidator, key, params
+		return vp
+	}
+	if _, ok := sv.tagMap[vp.validator]; ok {
+		vp.kind, vp.key = tagValidator, vp.validator
+	}
+	return vp
+}
+
+// matchParamTag finds the registered param validator whose regex matches the option.
+// Keys are tried in sorted order so the result doesn't depend on map iteration.
+func matchParamTag(validator string, regexMap map[string]*regexp.Regexp, registered func(string) bool) (string, []string, bool) {
+	keys := make([]string, 0, len(regexMap))
+	for key := range regexMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		ps := regexMap[key].FindStringSubmatch(validator)
+		if len(ps) == 0 || !registered(key) {
+			continue
+		}
+		return key, ps[1:], true
+	}
+	return "", nil, false
+}
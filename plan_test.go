@@ -0,0 +1,108 @@
+package govalidator
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestStructPlanIsCached(t *testing.T) {
+	t.Parallel()
+
+	type Cached struct {
+		Email   string `valid:"email,required~Email is missing" json:"email"`
+		Size    string `valid:"!length(1|3)"`
+		private string `valid:"email"`
+	}
+
+	v := New()
+	p := v.structPlan(reflect.TypeOf(Cached{}), "")
+	if p != v.structPlan(reflect.TypeOf(Cached{}), "") {
+		t.Error("Expected the struct plan to be reused")
+	}
+	if len(p.fields) != 2 {
+		t.Fatalf("Expected private fields to be skipped, got %d fields", len(p.fields))
+	}
+
+	email := p.fields[0]
+	if email.jsonName != "email" || len(email.validators) != 1 || email.validators[0].kind != tagValidator {
+		t.Errorf("Unexpected plan for Email: %+v", email)
+	}
+	if email.options["required"].customErrorMessage != "Email is missing" {
+		t.Errorf("Expected custom message to be kept, got %q", email.options["required"].customErrorMessage)
+	}
+
+	size := p.fields[1].validators[0]
+	if size.kind != paramValidator || size.key != "length" || !size.negate ||
+		!reflect.DeepEqual(size.params, []string{"1", "3"}) || size.name != "!length" {
+		t.Errorf("Unexpected plan for Size: %+v", size)
+	}
+}
+
+func TestStructPlanPicksUpNewValidators(t *testing.T) {
+	t.Parallel()
+
+	type Late struct {
+		Name string `valid:"lateduck"`
+	}
+
+	v := New()
+	if ok, _ := v.ValidateStruct(Late{Name: "duck"}); ok {
+		t.Error("Expected unknown validator to fail")
+	}
+
+	v.AddValidator("lateduck", func(str string) bool {
+		return str == "duck"
+	})
+	if ok, err := v.ValidateStruct(Late{Name: "duck"}); !ok {
+		t.Errorf("Expected validator registered after the first call to be used, got %v", err)
+	}
+
+	v.AddValidator("lateduck", func(str string) bool {
+		return str == "goose"
+	})
+	if ok, _ := v.ValidateStruct(Late{Name: "duck"}); ok {
+		t.Error("Expected replaced validator to be used")
+	}
+}
+
+func TestStructPlanPicksUpRegistrations(t *testing.T) {
+	t.Parallel()
+
+	type Registered struct {
+		Name   string `valid:"goose"`
+		Animal string `valid:"animal(duck)"`
+	}
+
+	v := New()
+	v.AddValidator("duck", IsAlpha)
+	v.AddParamValidator("animal", regexp.MustCompile(`^animal\((\w+)\)$`), func(str string, params ...string) bool {
+		return str == params[0]
+	})
+	if ok, _ := v.ValidateStruct(Registered{Name: "goose", Animal: "duck"}); ok {
+		t.Error("Expected unknown validator to fail")
+	}
+
+	// the number of registered validators doesn't change
+	delete(v.tagMap, "duck")
+	v.AddValidator("goose", func(str string) bool {
+		return str == "goose"
+	})
+	if ok, err := v.ValidateStruct(Registered{Name: "goose", Animal: "duck"}); !ok {
+		t.Errorf("Expected validator registered in place of another to be used, got %v", err)
+	}
+
+	v.AddParamValidator("animal", regexp.MustCompile(`^animal\((\w+)\)$`), func(str string, params ...string) bool {
+		return str != params[0]
+	})
+	if ok, _ := v.ValidateStruct(Registered{Name: "goose", Animal: "duck"}); ok {
+		t.Error("Expected replaced param validator to be used")
+	}
+
+	v.AddParamValidator("animal", regexp.MustCompile(`^animal\(\w+\)$`), func(str string, params ...string) bool {
+		return len(params) == 0
+	})
+	if ok, err := v.ValidateStruct(Registered{Name: "goose", Animal: "duck"}); !ok {
+		t.Errorf("Expected replaced pattern to be used, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package govalidator
+
+// ProblemContentType is the media type of ProblemDetails documents.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details document describing a failed validation.
+// The failures are listed in the invalid-params extension member.
+type ProblemDetails struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// InvalidParam is a single entry of the invalid-params extension.
+type InvalidParam struct {
+	// Name is the dotted path of the field, e.g. "address.street"
+	Name      string   `json:"name"`
+	Reason    string   `json:"reason"`
+	Validator string   `json:"validator,omitempty"`
+	Code      string   `json:"code,omitempty"`
+	Params    []string `json:"params,omitempty"`
+}
+
+// NewProblemDetails builds a 400 Bad Request problem document from the error returned by
+// ValidateStruct or ValidateMap. The invalid params are listed in the order the errors were
+// reported, which is the declaration order of the struct fields.
+//
+//	if _, err := govalidator.ValidateStruct(req); err != nil {
+//	    w.Header().Set("Content-Type", govalidator.ProblemContentType)
+//	    w.WriteHeader(http.StatusBadRequest)
+//	    json.NewEncoder(w).Encode(govalidator.NewProblemDetails(err))
+//	}
+func NewProblemDetails(err error) *ProblemDetails {
+	p := &ProblemDetails{
+		Type:          "about:blank",
+		Title:         "Your request parameters didn't validate.",
+		Status:        400, // Bad Request
+		InvalidParams: []InvalidParam{},
+	}
+	if err == nil {
+		return p
+	}
+	for _, e := range flattenErrors(err) {
+		ej := newErrorJSON(e)
+		p.InvalidParams = append(p.InvalidParams, InvalidParam{
+			Name:      ej.Path,
+			Reason:    ej.Message,
+			Validator: ej.Validator,
+			Code:      ej.Code,
+			Params:    ej.Params,
+		})
+	}
+	return p
+}
@@ -0,0 +1,17 @@
+package govalidator
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func ExampleNewProblemDetails() {
+	type Login struct {
+		Email string `valid:"email" json:"email"`
+	}
+
+	_, err := ValidateStruct(Login{Email: "nope"})
+	problem, _ := json.Marshal(NewProblemDetails(err))
+	fmt.Println(string(problem))
+	// Output: {"type":"about:blank","title":"Your request parameters didn't validate.","status":400,"invalid-params":[{"name":"email","reason":"email does not validate as email","validator":"email","code":"email"}]}
+}
@@ -0,0 +1,32 @@
+package govalidator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewProblemDetails(t *testing.T) {
+	t.Parallel()
+
+	type Login struct {
+		Email    string `valid:"email" json:"email"`
+		Password string `valid:"stringlength(8|64)" json:"password"`
+	}
+
+	_, err := ValidateStruct(Login{Email: "nope", Password: "short"})
+	actual, marshalErr := json.Marshal(NewProblemDetails(err))
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	expected := `{"type":"about:blank","title":"Your request parameters didn't validate.","status":400,"invalid-params":[` +
+		`{"name":"email","reason":"email does not validate as email","validator":"email","code":"email"},` +
+		`{"name":"password","reason":"password does not validate as stringlength(8|64)","validator":"stringlength","code":"stringlength","params":["8","64"]}` +
+		`]}`
+	if string(actual) != expected {
+		t.Errorf("Expected problem details to be\n%s\ngot\n%s", expected, actual)
+	}
+
+	if p := NewProblemDetails(nil); p.InvalidParams == nil || len(p.InvalidParams) != 0 {
+		t.Errorf("Expected no invalid params for a nil error, got %v", p.InvalidParams)
+	}
+}
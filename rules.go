@@ -0,0 +1,153 @@
+package govalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldRule is an option applied to a field by a RuleSet, the equivalent of an option of a `valid` tag.
+type FieldRule struct {
+	spec    string
+	message string
+}
+
+// Is returns the rule applying the validator registered under name in TagMap, ParamTagMap or
+// any other registry, with the given params, e.g. Is("alpha") or Is("stringlength", 1, 10).
+func Is(name string, params ...interface{}) FieldRule {
+	if len(params) == 0 {
+		return FieldRule{spec: name}
+	}
+	ps := make([]string, len(params))
+	for i, param := range params {
+		ps[i] = fmt.Sprint(param)
+	}
+	return FieldRule{spec: name + "(" + strings.Join(ps, "|") + ")"}
+}
+
+// Not returns the negation of rule, like the `!` prefix of a tag option.
+func Not(rule FieldRule) FieldRule {
+	return FieldRule{spec: "!" + rule.spec, message: rule.message}
+}
+
+// WithMessage returns the rule reporting message instead of the default message, like the `~` suffix
+// of a tag option.
+func (r FieldRule) WithMessage(message string) FieldRule {
+	r.message = message
+	return r
+}
+
+// Required returns the rule of the `required` option.
+func Required() FieldRule { return Is("required") }
+
+// Optional returns the rule of the `optional` option.
+func Optional() FieldRule { return Is("optional") }
+
+// EmailAddress returns the rule of the `email` option; Email is the name of its pattern.
+func EmailAddress() FieldRule { return Is("email") }
+
+// Between returns the rule of the `range(min|max)` option.
+func Between(min, max interface{}) FieldRule { return Is("range", min, max) }
+
+// LengthBetween returns the rule of the `stringlength(min|max)` option.
+func LengthBetween(min, max int) FieldRule { return Is("stringlength", min, max) }
+
+// Matching returns the rule of the `matches(pattern)` option.
+func Matching(pattern string) FieldRule { return Is("matches", pattern) }
+
+// In returns the rule of the `in(values)` option.
+func In(values ...interface{}) FieldRule { return Is("in", values...) }
+
+// RuleSet validates a struct with rules declared in code instead of `valid` tags, for types
+// that can't be tagged such as generated code.
+type RuleSet struct {
+	sv     *StructValidator
+	val    reflect.Value
+	fields []fieldRules
+	err    error
+}
+
+// fieldRules are the rules given to the field at index, and their plan.
+type fieldRules struct {
+	index []int
+	rules []FieldRule
+	plan  *tagPlan
+}
+
+// Rules returns a RuleSet for the struct s points to, using the package-level validators.
+func Rules(s interface{}) *RuleSet {
+	return defaultValidator.Rules(s)
+}
+
+// Rules returns a RuleSet for the struct s points to, using the validators and policies configured on sv.
+func (sv *StructValidator) Rules(s interface{}) *RuleSet {
+	r := &RuleSet{sv: sv}
+	val := reflect.ValueOf(s)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		r.err = fmt.Errorf("function only accepts pointers to structs; got %T", s)
+		return r
+	}
+	r.val = val.Elem()
+	return r
+}
+
+// Field adds rules to the field that field points to, which must be a field of the struct, or a
+// promoted field of an embedded struct. The rules take the place of the `valid` tag of the field.
+// They are compiled here, so the validators they use have to be registered beforehand.
+func (r *RuleSet) Field(field interface{}, rules ...FieldRule) *RuleSet {
+	if r.err != nil {
+		return r
+	}
+	index, ok := r.fieldIndex(reflect.ValueOf(field))
+	if !ok {
+		r.err = fmt.Errorf("field has to be a pointer to a field of %s; got %T", r.val.Type(), field)
+		return r
+	}
+	for i := range r.fields {
+		if reflect.DeepEqual(r.fields[i].index, index) {
+			r.fields[i].rules = append(r.fields[i].rules, rules...)
+			r.fields[i].plan = r.sv.compileRules(r.fields[i].rules)
+			return r
+		}
+	}
+	r.fields = append(r.fields, fieldRules{index: index, rules: rules, plan: r.sv.compileRules(rules)})
+	return r
+}
+
+// fieldIndex finds the field of the struct ptr points to.
+func (r *RuleSet) fieldIndex(ptr reflect.Value) ([]int, bool) {
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return nil, false
+	}
+	for _, f := range r.sv.structPlan(r.val.Type(), "").fields {
+		v := fieldByIndex(r.val, f.index)
+		if v.CanAddr() && v.UnsafeAddr() == ptr.Pointer() && v.Type() == ptr.Type().Elem() {
+			return f.index, true
+		}
+	}
+	return nil, false
+}
+
+// Validate validates the struct like ValidateStruct, applying the rules to their fields.
+func (r *RuleSet) Validate() (bool, error) {
+	return r.ValidateCtx(context.Background())
+}
+
+// ValidateCtx is the context-aware variant of Validate, see ValidateStructCtx.
+func (r *RuleSet) ValidateCtx(ctx context.Context) (bool, error) {
+	if r.err != nil {
+		return false, r.err
+	}
+	plan := *r.sv.structPlan(r.val.Type(), groupsFromContext(ctx))
+	plan.fields = append([]fieldPlan(nil), plan.fields...)
+	for _, fr := range r.fields {
+		for i := range plan.fields {
+			if reflect.DeepEqual(plan.fields[i].index, fr.index) {
+				plan.fields[i].tagPlan = fr.plan
+			}
+		}
+	}
+	result, err := r.sv.validateFields(ctx, r.val, &plan)
+	return result, r.sv.translateErrors(ctx, err)
+}
@@ -0,0 +1,19 @@
+package govalidator
+
+import "fmt"
+
+func ExampleRules() {
+	// User comes from generated code that has no `valid` tags
+	type User struct {
+		Email string
+		Age   int
+	}
+
+	u := User{Email: "nope", Age: 7}
+	_, err := Rules(&u).
+		Field(&u.Email, Required(), EmailAddress()).
+		Field(&u.Age, Between(18, 130)).
+		Validate()
+	fmt.Println(err)
+	// Output: Age: 7 does not validate as range(18|130);Email: nope does not validate as email
+}
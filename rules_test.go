@@ -0,0 +1,112 @@
+package govalidator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ruleBase struct {
+	ID string
+}
+
+func TestRuleSet(t *testing.T) {
+	t.Parallel()
+
+	type Generated struct {
+		ruleBase
+		Email string `json:"email"`
+		Age   int
+		Code  string
+		Role  string
+	}
+	type Tagged struct {
+		ID    string `valid:"required"`
+		Email string `valid:"required,email" json:"email"`
+		Age   int    `valid:"range(18|130)"`
+		Code  string `valid:"!alpha~Code must not be letters only"`
+		Role  string `valid:"in(admin|user)"`
+	}
+
+	var tests = []struct {
+		generated Generated
+		tagged    Tagged
+	}{
+		{Generated{ruleBase{"1"}, "me@example.com", 20, "a1", "user"}, Tagged{"1", "me@example.com", 20, "a1", "user"}},
+		{Generated{ruleBase{""}, "nope", 7, "abc", "root"}, Tagged{"", "nope", 7, "abc", "root"}},
+		{Generated{}, Tagged{}},
+	}
+	for _, test := range tests {
+		u := test.generated
+		actualOk, actualErr := Rules(&u).
+			Field(&u.ID, Required()).
+			Field(&u.Email, Required(), EmailAddress()).
+			Field(&u.Age, Between(18, 130)).
+			Field(&u.Code, Not(Is("alpha")).WithMessage("Code must not be letters only")).
+			Field(&u.Role, In("admin", "user")).
+			Validate()
+		expectedOk, expectedErr := ValidateStruct(test.tagged)
+		if actualOk != expectedOk || !reflect.DeepEqual(actualErr, expectedErr) {
+			t.Errorf("Expected Rules(%+v) to return %v, %v, got %v, %v", u, expectedOk, expectedErr, actualOk, actualErr)
+		}
+	}
+}
+
+func TestRuleSetParams(t *testing.T) {
+	t.Parallel()
+
+	type Generated struct {
+		Name string
+		Tags []string
+	}
+	u := Generated{Name: "aaaa", Tags: []string{"a"}}
+	ok, err := Rules(&u).
+		Field(&u.Name, Matching("^a{1,3}$")).
+		Field(&u.Tags, Is("minitems", 2)).
+		Validate()
+	if ok || ErrorsByPath(err)["Name"] != "aaaa does not validate as matches(^a{1,3}$)" || ErrorsByPath(err)["Tags"] == "" {
+		t.Errorf("Expected the params to be kept as they are, got %v", err)
+	}
+}
+
+func TestRuleSetReuse(t *testing.T) {
+	t.Parallel()
+
+	type Generated struct {
+		Name string
+	}
+	u := Generated{Name: "abc"}
+	rules := Rules(&u).Field(&u.Name, Is("alpha")).Field(&u.Name, Required())
+	plan := rules.fields[0].plan
+	if plan == nil || plan.tag != "alpha,required" {
+		t.Fatalf("Expected the rules to be compiled by Field, got %+v", plan)
+	}
+	if ok, err := rules.Validate(); !ok {
+		t.Errorf("Expected %+v to be valid, got %v", u, err)
+	}
+	u.Name = "a1"
+	if ok, _ := rules.Validate(); ok {
+		t.Errorf("Expected %+v to be invalid", u)
+	}
+	if rules.fields[0].plan != plan {
+		t.Error("Expected Validate to reuse the compiled rules")
+	}
+}
+
+func TestRuleSetErrors(t *testing.T) {
+	t.Parallel()
+
+	type Generated struct {
+		Name string
+	}
+	u, other := Generated{}, Generated{}
+	if _, err := Rules(u).Validate(); err == nil || !strings.Contains(err.Error(), "pointers to structs") {
+		t.Errorf("Expected an error for a struct value, got %v", err)
+	}
+	if _, err := Rules(&u).Field(&other.Name, Required()).Validate(); err == nil || !strings.Contains(err.Error(), "field of") {
+		t.Errorf("Expected an error for a field of another struct, got %v", err)
+	}
+	if _, err := Rules(&u).Field(u.Name, Required()).Validate(); err == nil {
+		t.Error("Expected an error for a field value")
+	}
+}
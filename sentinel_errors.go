@@ -0,0 +1,192 @@
+package govalidator
+
+import (
+	"errors"
+)
+
+// Sentinel errors matched by errors.Is against the Error reported for a failure, e.g.
+//
+//	if errors.Is(err, govalidator.ErrEmail) {
+//
+// The conditional requirements also match ErrRequired.
+var (
+	ErrRequired         = errors.New("non zero value required")
+	ErrRequiredIf       = errors.New("non zero value required by required_if")
+	ErrRequiredUnless   = errors.New("non zero value required by required_unless")
+	ErrRequiredWith     = errors.New("non zero value required by required_with")
+	ErrRequiredWithout  = errors.New("non zero value required by required_without")
+	ErrNoValidator      = errors.New("no validation defined")
+	ErrInvalidValidator = errors.New("invalid validator")
+	ErrUnsupportedType  = errors.New("unsupported type")
+	ErrUnknownField     = errors.New("unknown field")
+	ErrIncomparable     = errors.New("incomparable fields")
+
+	ErrIMEI               = errors.New("does not validate as IMEI")
+	ErrISO3166Alpha2      = errors.New("does not validate as ISO3166Alpha2")
+	ErrISO3166Alpha3      = errors.New("does not validate as ISO3166Alpha3")
+	ErrISO4217            = errors.New("does not validate as ISO4217")
+	ErrAlpha              = errors.New("does not validate as alpha")
+	ErrAlphanum           = errors.New("does not validate as alphanum")
+	ErrASCII              = errors.New("does not validate as ascii")
+	ErrBase64             = errors.New("does not validate as base64")
+	ErrCreditCard         = errors.New("does not validate as creditcard")
+	ErrDataURI            = errors.New("does not validate as datauri")
+	ErrDialString         = errors.New("does not validate as dialstring")
+	ErrDNSName            = errors.New("does not validate as dns")
+	ErrEmail              = errors.New("does not validate as email")
+	ErrEqField            = errors.New("does not validate as eqfield")
+	ErrFloat              = errors.New("does not validate as float")
+	ErrFullWidth          = errors.New("does not validate as fullwidth")
+	ErrGteField           = errors.New("does not validate as gtefield")
+	ErrGtField            = errors.New("does not validate as gtfield")
+	ErrHalfWidth          = errors.New("does not validate as halfwidth")
+	ErrHexadecimal        = errors.New("does not validate as hexadecimal")
+	ErrHexcolor           = errors.New("does not validate as hexcolor")
+	ErrHost               = errors.New("does not validate as host")
+	ErrIn                 = errors.New("does not validate as in")
+	ErrInt                = errors.New("does not validate as int")
+	ErrIP                 = errors.New("does not validate as ip")
+	ErrIPv4               = errors.New("does not validate as ipv4")
+	ErrIPv6               = errors.New("does not validate as ipv6")
+	ErrISBN10             = errors.New("does not validate as isbn10")
+	ErrISBN13             = errors.New("does not validate as isbn13")
+	ErrItems              = errors.New("does not validate as items")
+	ErrJSON               = errors.New("does not validate as json")
+	ErrJWT                = errors.New("does not validate as jwt")
+	ErrLatitude           = errors.New("does not validate as latitude")
+	ErrLength             = errors.New("does not validate as length")
+	ErrLongitude          = errors.New("does not validate as longitude")
+	ErrLowerCase          = errors.New("does not validate as lowercase")
+	ErrLteField           = errors.New("does not validate as ltefield")
+	ErrLtField            = errors.New("does not validate as ltfield")
+	ErrMAC                = errors.New("does not validate as mac")
+	ErrMatches            = errors.New("does not validate as matches")
+	ErrMaxItems           = errors.New("does not validate as maxitems")
+	ErrMaxStringLength    = errors.New("does not validate as maxstringlength")
+	ErrMinItems           = errors.New("does not validate as minitems")
+	ErrMinStringLength    = errors.New("does not validate as minstringlength")
+	ErrMultibyte          = errors.New("does not validate as multibyte")
+	ErrNeField            = errors.New("does not validate as nefield")
+	ErrNotNull            = errors.New("does not validate as notnull")
+	ErrNull               = errors.New("does not validate as null")
+	ErrNumeric            = errors.New("does not validate as numeric")
+	ErrPort               = errors.New("does not validate as port")
+	ErrPrintableASCII     = errors.New("does not validate as printableascii")
+	ErrRange              = errors.New("does not validate as range")
+	ErrRequestURI         = errors.New("does not validate as requri")
+	ErrRequestURL         = errors.New("does not validate as requrl")
+	ErrRFC3339            = errors.New("does not validate as rfc3339")
+	ErrRFC3339WithoutZone = errors.New("does not validate as rfc3339WithoutZone")
+	ErrRGBcolor           = errors.New("does not validate as rgbcolor")
+	ErrRsaPub             = errors.New("does not validate as rsapub")
+	ErrRuneLength         = errors.New("does not validate as runelength")
+	ErrSemver             = errors.New("does not validate as semver")
+	ErrSSN                = errors.New("does not validate as ssn")
+	ErrStringLength       = errors.New("does not validate as stringlength")
+	ErrType               = errors.New("does not validate as type")
+	ErrULID               = errors.New("does not validate as ulid")
+	ErrUnique             = errors.New("does not validate as unique")
+	ErrUpperCase          = errors.New("does not validate as uppercase")
+	ErrURL                = errors.New("does not validate as url")
+	ErrUTFDigit           = errors.New("does not validate as utfdigit")
+	ErrUTFLetter          = errors.New("does not validate as utfletter")
+	ErrUTFLetterNumeric   = errors.New("does not validate as utfletternum")
+	ErrUTFNumeric         = errors.New("does not validate as utfnumeric")
+	ErrUUID               = errors.New("does not validate as uuid")
+	ErrUUIDv3             = errors.New("does not validate as uuidv3")
+	ErrUUIDv4             = errors.New("does not validate as uuidv4")
+	ErrUUIDv5             = errors.New("does not validate as uuidv5")
+	ErrVariableWidth      = errors.New("does not validate as variablewidth")
+	ErrYYYYMMDD           = errors.New("does not validate as yyyymmdd")
+)
+
+// sentinelErrors maps the codes of Error to the sentinel errors.
+var sentinelErrors = map[string]error{
+	CodeRequired:         ErrRequired,
+	"required_if":        ErrRequiredIf,
+	"required_unless":    ErrRequiredUnless,
+	"required_with":      ErrRequiredWith,
+	"required_without":   ErrRequiredWithout,
+	CodeNoValidator:      ErrNoValidator,
+	CodeInvalidValidator: ErrInvalidValidator,
+	CodeUnsupportedKind:  ErrUnsupportedType,
+	CodeUnknownField:     ErrUnknownField,
+	CodeIncomparable:     ErrIncomparable,
+	"IMEI":               ErrIMEI,
+	"ISO3166Alpha2":      ErrISO3166Alpha2,
+	"ISO3166Alpha3":      ErrISO3166Alpha3,
+	"ISO4217":            ErrISO4217,
+	"alpha":              ErrAlpha,
+	"alphanum":           ErrAlphanum,
+	"ascii":              ErrASCII,
+	"base64":             ErrBase64,
+	"creditcard":         ErrCreditCard,
+	"datauri":            ErrDataURI,
+	"dialstring":         ErrDialString,
+	"dns":                ErrDNSName,
+	"email":              ErrEmail,
+	"eqfield":            ErrEqField,
+	"float":              ErrFloat,
+	"fullwidth":          ErrFullWidth,
+	"gtefield":           ErrGteField,
+	"gtfield":            ErrGtField,
+	"halfwidth":          ErrHalfWidth,
+	"hexadecimal":        ErrHexadecimal,
+	"hexcolor":           ErrHexcolor,
+	"host":               ErrHost,
+	"in":                 ErrIn,
+	"int":                ErrInt,
+	"ip":                 ErrIP,
+	"ipv4":               ErrIPv4,
+	"ipv6":               ErrIPv6,
+	"isbn10":             ErrISBN10,
+	"isbn13":             ErrISBN13,
+	"items":              ErrItems,
+	"json":               ErrJSON,
+	"jwt":                ErrJWT,
+	"latitude":           ErrLatitude,
+	"length":             ErrLength,
+	"longitude":          ErrLongitude,
+	"lowercase":          ErrLowerCase,
+	"ltefield":           ErrLteField,
+	"ltfield":            ErrLtField,
+	"mac":                ErrMAC,
+	"matches":            ErrMatches,
+	"maxitems":           ErrMaxItems,
+	"maxstringlength":    ErrMaxStringLength,
+	"minitems":           ErrMinItems,
+	"minstringlength":    ErrMinStringLength,
+	"multibyte":          ErrMultibyte,
+	"nefield":            ErrNeField,
+	"notnull":            ErrNotNull,
+	"null":               ErrNull,
+	"numeric":            ErrNumeric,
+	"port":               ErrPort,
+	"printableascii":     ErrPrintableASCII,
+	"range":              ErrRange,
+	"requri":             ErrRequestURI,
+	"requrl":             ErrRequestURL,
+	"rfc3339":            ErrRFC3339,
+	"rfc3339WithoutZone": ErrRFC3339WithoutZone,
+	"rgbcolor":           ErrRGBcolor,
+	"rsapub":             ErrRsaPub,
+	"runelength":         ErrRuneLength,
+	"semver":             ErrSemver,
+	"ssn":                ErrSSN,
+	"stringlength":       ErrStringLength,
+	"type":               ErrType,
+	"ulid":               ErrULID,
+	"unique":             ErrUnique,
+	"uppercase":          ErrUpperCase,
+	"url":                ErrURL,
+	"utfdigit":           ErrUTFDigit,
+	"utfletter":          ErrUTFLetter,
+	"utfletternum":       ErrUTFLetterNumeric,
+	"utfnumeric":         ErrUTFNumeric,
+	"uuid":               ErrUUID,
+	"uuidv3":             ErrUUIDv3,
+	"uuidv4":             ErrUUIDv4,
+	"uuidv5":             ErrUUIDv5,
+	"variablewidth":      ErrVariableWidth,
+	"yyyymmdd":           ErrYYYYMMDD,
+}
@@ -0,0 +1,90 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// structRulesMap holds the rules registered with RegisterStructRules by struct type.
+type structRulesMap struct {
+	rules map[reflect.Type]map[string]string
+	// registrations counts the calls to Set, so the compiled plans are dropped on changes
+	registrations int64
+
+	sync.RWMutex
+}
+
+func (sm *structRulesMap) Get(t reflect.Type) map[string]string {
+	sm.RLock()
+	defer sm.RUnlock()
+	return sm.rules[t]
+}
+
+func (sm *structRulesMap) Set(t reflect.Type, rules map[string]string) {
+	sm.Lock()
+	defer sm.Unlock()
+	sm.rules[t] = rules
+	sm.registrations++
+}
+
+func (sm *structRulesMap) Registrations() int64 {
+	sm.RLock()
+	defer sm.RUnlock()
+	return sm.registrations
+}
+
+// RegisterStructRules registers validation rules for the fields of a struct type that can't be tagged,
+// such as a type of another package. The type is given as a reflect.Type or a sample value. rules maps
+// field names to options in the syntax of the `valid` tag; they are used for the fields having no tag,
+// including the promoted fields of embedded structs. Registering rules for a type again replaces them.
+func RegisterStructRules(sample interface{}, rules map[string]string) error {
+	return defaultValidator.RegisterStructRules(sample, rules)
+}
+
+// RegisterStructRules registers validation rules on this instance, see RegisterStructRules.
+func (sv *StructValidator) RegisterStructRules(sample interface{}, rules map[string]string) error {
+	t, err := structType(sample)
+	if err != nil {
+		return err
+	}
+	copied := make(map[string]string, len(rules))
+	for name, rule := range rules {
+		if _, ok := t.FieldByName(name); !ok {
+			return fmt.Errorf("%s has no field %s", t, name)
+		}
+		copied[name] = rule
+	}
+	sv.structRules.Set(t, copied)
+	return nil
+}
+
+// structType returns the struct type given as a reflect.Type or a sample value, which may be a pointer.
+func structType(sample interface{}) (reflect.Type, error) {
+	t, ok := sample.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(sample)
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("function only accepts structs; got %v", t)
+	}
+	return t, nil
+}
+
+// mergeRules returns the rules of an embedded struct type overridden by the rules of the outer type.
+func mergeRules(rules, outerRules map[string]string) map[string]string {
+	if len(outerRules) == 0 {
+		return rules
+	}
+	merged := make(map[string]string, len(rules)+len(outerRules))
+	for name, rule := range rules {
+		merged[name] = rule
+	}
+	for name, rule := range outerRules {
+		merged[name] = rule
+	}
+	return merged
+}
@@ -0,0 +1,88 @@
+package govalidator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type externalAddress struct {
+	Street string
+	Zip    string `valid:"numeric"`
+}
+
+type externalCustomer struct {
+	externalAddress
+	Name  string `json:"name"`
+	Email string
+}
+
+func TestRegisterStructRules(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	if err := v.RegisterStructRules(externalCustomer{}, map[string]string{
+		"Name":   "required",
+		"Email":  "email~invalid email",
+		"Street": "required",
+		"Zip":    "required",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		param    externalCustomer
+		expected map[string]string
+	}{
+		{externalCustomer{externalAddress{"Main St", "12345"}, "Ann", "ann@example.com"}, map[string]string{}},
+		{externalCustomer{externalAddress{"", "abc"}, "", "nope"}, map[string]string{
+			"Street": "non zero value required",
+			"Zip":    "abc does not validate as numeric",
+			"name":   "non zero value required",
+			"Email":  "invalid email",
+		}},
+	}
+	for _, test := range tests {
+		_, err := v.ValidateStruct(test.param)
+		if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected ValidateStruct(%+v) to report %v, got %v", test.param, test.expected, actual)
+		}
+	}
+
+	// rules registered for the embedded type apply wherever it is embedded
+	if err := v.RegisterStructRules(reflect.TypeOf(&externalAddress{}), map[string]string{"Zip": "required"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.RegisterStructRules(&externalCustomer{}, map[string]string{"Name": "required"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := v.ValidateStruct(externalCustomer{externalAddress{"", "x"}, "", ""})
+	expected := map[string]string{"Zip": "x does not validate as numeric", "name": "non zero value required"}
+	if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected the registered rules to be replaced, got %v", actual)
+	}
+
+	if ok, err := ValidateStruct(externalCustomer{}); !ok {
+		t.Errorf("Expected the rules of another instance to be ignored, got %v", err)
+	}
+}
+
+func TestRegisterStructRulesErrors(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		sample   interface{}
+		rules    map[string]string
+		expected string
+	}{
+		{"string", nil, "only accepts structs"},
+		{nil, nil, "only accepts structs"},
+		{externalCustomer{}, map[string]string{"Phone": "required"}, "has no field Phone"},
+	}
+	for _, test := range tests {
+		err := New().RegisterStructRules(test.sample, test.rules)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Expected RegisterStructRules(%v) to fail with %q, got %v", test.sample, test.expected, err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package govalidator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync/atomic"
+)
+
+// StructValidator validates structs and maps using its own tag registries and policies.
+// Unlike the package-level functions, which share global configuration, every
+// StructValidator returned by New is isolated, so several libraries in the same
+// binary can register custom tags without stomping on each other.
+// Validators should be registered before the instance is used concurrently.
+type StructValidator struct {
+	tagName                 string
+	fieldsRequiredByDefault bool
+	nilPtrAllowedByRequired bool
+	allErrors               bool
+	redactedValues          bool
+	unexportedFields        bool
+	translator              Translator
+	locale                  string
+
+	tagMap                    map[string]Validator
+	paramTagMap               map[string]ParamValidator
+	paramTagRegexMap          map[string]*regexp.Regexp
+	interfaceParamTagMap      map[string]InterfaceParamValidator
+	interfaceParamTagRegexMap map[string]*regexp.Regexp
+	customTypeTagMap          *customTypeTagMap
+	structRules               *structRulesMap
+
+	// registrations counts the validators added with AddValidator and AddParamValidator,
+	// so the compiled plans are dropped on changes
+	registrations atomic.Int64
+	// cache holds the *planCache with compiled per-type validation plans
+	cache atomic.Value
+}
+
+// Option configures a StructValidator created by New.
+type Option func(*StructValidator)
+
+// WithTagName sets the struct tag key the validator reads rules from (default is "valid").
+func WithTagName(name string) Option {
+	return func(sv *StructValidator) {
+		sv.tagName = name
+	}
+}
+
+// WithFieldsRequiredByDefault is the per-instance equivalent of SetFieldsRequiredByDefault.
+func WithFieldsRequiredByDefault(value bool) Option {
+	return func(sv *StructValidator) {
+		sv.fieldsRequiredByDefault = value
+	}
+}
+
+// WithNilPtrAllowedByRequired is the per-instance equivalent of SetNilPtrAllowedByRequired.
+func WithNilPtrAllowedByRequired(value bool) Option {
+	return func(sv *StructValidator) {
+		sv.nilPtrAllowedByRequired = value
+	}
+}
+
+// WithAllErrors is the per-instance equivalent of SetAllErrors.
+func WithAllErrors(value bool) Option {
+	return func(sv *StructValidator) {
+		sv.allErrors = value
+	}
+}
+
+// WithRedactedValues is the per-instance equivalent of SetRedactedValues.
+func WithRedactedValues(value bool) Option {
+	return func(sv *StructValidator) {
+		sv.redactedValues = value
+	}
+}
+
+// WithUnexportedFields is the per-instance equivalent of SetUnexportedFields.
+func WithUnexportedFields(value bool) Option {
+	return func(sv *StructValidator) {
+		sv.unexportedFields = value
+	}
+}
+
+// WithTranslator is the per-instance equivalent of SetTranslator.
+func WithTranslator(t Translator) Option {
+	return func(sv *StructValidator) {
+		sv.translator = t
+	}
+}
+
+// WithLocale is the per-instance equivalent of SetLocale.
+func WithLocale(locale string) Option {
+	return func(sv *StructValidator) {
+		sv.locale = locale
+	}
+}
+
+// defaultValidator backs the package-level functions. It shares the global tag maps,
+// so validators added to TagMap, ParamTagMap etc. are visible to it.
+var defaultValidator = &StructValidator{
+	tagName:                   tagName,
+	tagMap:                    TagMap,
+	paramTagMap:               ParamTagMap,
+	paramTagRegexMap:          ParamTagRegexMap,
+	interfaceParamTagMap:      InterfaceParamTagMap,
+	interfaceParamTagRegexMap: InterfaceParamTagRegexMap,
+	customTypeTagMap:          CustomTypeTagMap,
+	structRules:               &structRulesMap{rules: make(map[reflect.Type]map[string]string)},
+}
+
+// New returns a StructValidator with its own copy of the built-in validators.
+// Validators registered on the returned instance are not visible to the package-level
+// functions and vice versa.
+func New(opts ...Option) *StructValidator {
+	sv := &StructValidator{
+		tagName:                   tagName,
+		tagMap:                    make(map[string]Validator, len(TagMap)),
+		paramTagMap:               make(map[string]ParamValidator, len(ParamTagMap)),
+		paramTagRegexMap:          make(map[string]*regexp.Regexp, len(ParamTagRegexMap)),
+		interfaceParamTagMap:      make(map[string]InterfaceParamValidator, len(InterfaceParamTagMap)),
+		interfaceParamTagRegexMap: make(map[string]*regexp.Regexp, len(InterfaceParamTagRegexMap)),
+		customTypeTagMap:          &customTypeTagMap{validators: make(map[string]CustomTypeValidator)},
+		structRules:               &structRulesMap{rules: make(map[reflect.Type]map[string]string)},
+	}
+	for k, v := range TagMap {
+		sv.tagMap[k] = v
+	}
+	for k, v := range ParamTagMap {
+		sv.paramTagMap[k] = v
+	}
+	for k, v := range ParamTagRegexMap {
+		sv.paramTagRegexMap[k] = v
+	}
+	for k, v := range InterfaceParamTagMap {
+		sv.interfaceParamTagMap[k] = v
+	}
+	for k, v := range InterfaceParamTagRegexMap {
+		sv.interfaceParamTagRegexMap[k] = v
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	return sv
+}
+
+// AddValidator registers a validator available as the tag `name` to the package-level functions.
+// Unlike writing to TagMap, it also takes effect once structs have been validated, when a
+// validator registered earlier under the same name is replaced.
+func AddValidator(name string, fn Validator) {
+	defaultValidator.AddValidator(name, fn)
+}
+
+// AddParamValidator registers a param validator to the package-level functions, like writing to
+// ParamTagMap and ParamTagRegexMap, see AddValidator.
+func AddParamValidator(name string, pattern *regexp.Regexp, fn ParamValidator) {
+	defaultValidator.AddParamValidator(name, pattern, fn)
+}
+
+// AddValidator registers a validator available as the tag `name` on this instance.
+// It panics if name can't be used in tags, such as a name containing `@`, which starts
+// the groups of a tag option.
+func (sv *StructValidator) AddValidator(name string, fn Validator) {
+	mustBeValidTag(name)
+	sv.tagMap[name] = fn
+	sv.registrations.Add(1)
+}
+
+// AddParamValidator registers a param validator on this instance. The pattern must match
+// the whole tag option and capture the params, e.g. `^animal\((\w+)\)$`.
+func (sv *StructValidator) AddParamValidator(name string, pattern *regexp.Regexp, fn ParamValidator) {
+	mustBeValidTag(name)
+	sv.paramTagMap[name] = fn
+	sv.paramTagRegexMap[name] = pattern
+	sv.registrations.Add(1)
+}
+
+// AddCustomTypeValidator registers a custom type validator on this instance.
+func (sv *StructValidator) AddCustomTypeValidator(name string, fn CustomTypeValidator) {
+	mustBeValidTag(name)
+	sv.customTypeTagMap.Set(name, fn)
+}
+
+// AddCustomTypeValidatorCtx registers a context-aware custom type validator on this instance.
+func (sv *StructValidator) AddCustomTypeValidatorCtx(name string, fn CustomTypeValidatorCtx) {
+	mustBeValidTag(name)
+	sv.customTypeTagMap.SetCtx(name, fn)
+}
+
+// mustBeValidTag panics if name can't be used as a validator name in tags.
+func mustBeValidTag(name string) {
+	if !isValidTag(name) {
+		panic(fmt.Sprintf("govalidator: invalid validator name %q", name))
+	}
+}
@@ -0,0 +1,14 @@
+package govalidator
+
+func ExampleNew() {
+	v := New(WithFieldsRequiredByDefault(true))
+	v.AddValidator("duck", func(str string) bool {
+		return str == "duck"
+	})
+
+	type Post struct {
+		Message string `valid:"duck"`
+	}
+
+	_, _ = v.ValidateStruct(Post{Message: "duck"}) // true, nil
+}
@@ -0,0 +1,168 @@
+package govalidator
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewIsolatesTagMaps(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.AddValidator("isolatedduck", func(str string) bool {
+		return str == "duck"
+	})
+
+	type Post struct {
+		Message string `valid:"isolatedduck"`
+	}
+
+	if ok, err := v.ValidateStruct(Post{Message: "duck"}); !ok || err != nil {
+		t.Errorf("Expected instance validation to pass, got %v %v", ok, err)
+	}
+	if ok, err := v.ValidateStruct(Post{Message: "goose"}); ok || err == nil {
+		t.Errorf("Expected instance validation to fail, got %v %v", ok, err)
+	}
+	if _, ok := TagMap["isolatedduck"]; ok {
+		t.Error("Expected instance validator not to leak into TagMap")
+	}
+	if ok, _ := ValidateStruct(Post{Message: "duck"}); ok {
+		t.Error("Expected package-level ValidateStruct not to know the instance validator")
+	}
+}
+
+func TestNewParamAndCustomTypeValidators(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.AddParamValidator("animal", regexp.MustCompile(`^animal\((\w+)\)$`), func(str string, params ...string) bool {
+		return len(params) == 1 && str == params[0]
+	})
+	v.AddCustomTypeValidator("positive", func(i interface{}, o interface{}) bool {
+		return i.(int) > 0
+	})
+
+	type Pet struct {
+		Kind  string `valid:"animal(dog)"`
+		Count int    `valid:"positive"`
+	}
+
+	var tests = []struct {
+		param    Pet
+		expected bool
+	}{
+		{Pet{Kind: "dog", Count: 1}, true},
+		{Pet{Kind: "cat", Count: 1}, false},
+		{Pet{Kind: "dog", Count: -1}, false},
+	}
+	for _, test := range tests {
+		actual, err := v.ValidateStruct(test.param)
+		if actual != test.expected {
+			t.Errorf("Expected ValidateStruct(%v) to be %v, got %v (%v)", test.param, test.expected, actual, err)
+		}
+	}
+}
+
+func TestAddValidatorInvalidName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"animal@zoo", "", "a,b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected AddValidator(%q) to panic", name)
+				}
+			}()
+			New().AddValidator(name, func(str string) bool { return true })
+		}()
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Name  string `rules:"required"`
+		Email string
+		Alias *string `rules:"required"`
+	}
+
+	v := New(WithTagName("rules"))
+	if ok, _ := v.ValidateStruct(Account{}); ok {
+		t.Error("Expected required rule from custom tag name to fail")
+	}
+	if ok, err := v.ValidateStruct(Account{Name: "a", Alias: new(string)}); ok {
+		t.Errorf("Expected empty Alias to fail, got %v", err)
+	}
+
+	v = New(WithTagName("rules"), WithNilPtrAllowedByRequired(true))
+	if ok, err := v.ValidateStruct(Account{Name: "a"}); !ok {
+		t.Errorf("Expected nil Alias to be allowed, got %v", err)
+	}
+
+	v = New(WithTagName("rules"), WithFieldsRequiredByDefault(true), WithNilPtrAllowedByRequired(true))
+	if ok, _ := v.ValidateStruct(Account{Name: "a", Email: "a@b.c"}); ok {
+		t.Error("Expected untagged Email to fail when fields are required by default")
+	}
+	if ok, _ := ValidateStruct(Account{}); !ok {
+		t.Error("Expected instance options not to affect package-level validation")
+	}
+}
+
+func TestStructValidatorValidateMap(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.AddValidator("maponlyduck", func(str string) bool {
+		return str == "duck"
+	})
+
+	schema := map[string]interface{}{
+		"name": "required,maponlyduck",
+	}
+	if ok, err := v.ValidateMap(map[string]interface{}{"name": "duck"}, schema); !ok || err != nil {
+		t.Errorf("Expected map validation to pass, got %v %v", ok, err)
+	}
+	if ok, _ := v.ValidateMap(map[string]interface{}{"name": "goose"}, schema); ok {
+		t.Error("Expected map validation to fail")
+	}
+}
+
+func TestWithAllErrors(t *testing.T) {
+	t.Parallel()
+
+	type Account struct {
+		Login string   `valid:"stringlength(4|32),alphanum,lowercase"`
+		Tags  []string `valid:"alpha"`
+	}
+
+	account := Account{Login: "A-B", Tags: []string{"a", "1", "2"}}
+
+	_, err := New().ValidateStruct(account)
+	if got := len(ErrorsByField(err)); got != 2 {
+		t.Fatalf("Expected one error per field by default, got %v", err)
+	}
+	if errs := err.(Errors); len(errs) != 2 {
+		t.Fatalf("Expected 2 errors by default, got %v", err)
+	} else if _, ok := errs[0].(Error); !ok {
+		t.Errorf("Expected a single Error for Login by default, got %T", errs[0])
+	}
+
+	ok, err := New(WithAllErrors(true)).ValidateStruct(account)
+	if ok {
+		t.Fatal("Expected validation to fail")
+	}
+	errs := err.(Errors)
+	login, ok := errs[0].(Errors)
+	if !ok || len(login) != 3 {
+		t.Fatalf("Expected 3 errors for Login, got %v", errs[0])
+	}
+	for i, validator := range []string{"stringlength", "alphanum", "lowercase"} {
+		if name := login[i].(Error).Validator; name != validator {
+			t.Errorf("Expected error %d to come from %s, got %s", i, validator, name)
+		}
+	}
+	if tags, ok := errs[1].(Errors); !ok || len(tags) != 2 {
+		t.Errorf("Expected 2 errors for Tags, got %v", errs[1])
+	}
+}
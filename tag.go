@@ -0,0 +1,331 @@
+package govalidator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagOption is an option of a `valid` tag as parsed by ParseTag, e.g. `!length(1|10)@create~Bad length`.
+//
+// Options are separated by commas. Commas inside parentheses belong to the params, so
+// `matches(^[a-z]{1,3}$)` is a single option. A param or a message may be quoted with single
+// quotes, in which `\'` stands for a quote and `\\` for a backslash: `in('a,b'|c)` or
+// `~'Must be a, b or c'`. Outside of quotes a backslash keeps the next character from ending
+// an option, `\,`, `\~` and `\'` standing for the character alone; other escapes such as `\d`
+// are passed to the validator as they are.
+type TagOption struct {
+	// Name is the validator name, e.g. "length"
+	Name string
+	// Params are the params between the parentheses, nil when the option has none
+	Params  []string
+	Negated bool
+	Groups  []string
+	Message string
+	// Column is the 1-based position of the option in the tag
+	Column int
+}
+
+// Spec returns the option as it is passed to the validators, e.g. "!length(1|10)".
+func (o TagOption) Spec() string {
+	spec := o.Name
+	if o.Negated {
+		spec = "!" + spec
+	}
+	if o.Params != nil {
+		spec += "(" + strings.Join(o.Params, "|") + ")"
+	}
+	return spec
+}
+
+// TagError is a problem reported by ParseTag at a position of the tag.
+type TagError struct {
+	// Column is the 1-based position of the problem in the tag
+	Column int
+	Msg    string
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+// ParseTag parses a `valid` tag and checks its options against the package-level validators.
+// Unknown validators, malformed params and duplicate options are reported as Errors of *TagError,
+// along with the options that could be parsed.
+func ParseTag(tag string) ([]TagOption, error) {
+	return defaultValidator.ParseTag(tag)
+}
+
+// ParseTag parses a `valid` tag and checks its options against the validators of this instance,
+// see ParseTag.
+func (sv *StructValidator) ParseTag(tag string) ([]TagOption, error) {
+	if tag == "-" || strings.TrimSpace(tag) == "" {
+		return nil, nil
+	}
+	var options []TagOption
+	raws, errs := splitTagOptions(tag)
+	// seen holds the columns of the options of the current collection, keys or elements part
+	seen := make(map[string]int)
+	for _, raw := range raws {
+		if raw.text == "" {
+			errs = append(errs, &TagError{raw.column, "empty option"})
+			continue
+		}
+		opt, _, err := parseTagOption(raw.text, raw.column)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		options = append(options, opt)
+		switch opt.Name {
+		case "dive", "keys", "endkeys":
+			seen = make(map[string]int)
+			continue
+		}
+		if column, ok := seen[opt.Spec()]; ok {
+			errs = append(errs, &TagError{opt.Column, fmt.Sprintf("duplicate option %s, first at column %d", opt.Spec(), column)})
+			continue
+		}
+		seen[opt.Spec()] = opt.Column
+		if msg := sv.checkTagOption(opt); msg != "" {
+			errs = append(errs, &TagError{opt.Column, msg})
+		}
+	}
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			return errs[i].(*TagError).Column < errs[j].(*TagError).Column
+		})
+		return options, errs
+	}
+	return options, nil
+}
+
+// checkTagOption returns the problem of an option whose syntax is valid, or "" when it can be applied.
+func (sv *StructValidator) checkTagOption(opt TagOption) string {
+	switch opt.Name {
+	case "required", "optional", "redact", "dive", "keys", "endkeys":
+		if opt.Params != nil {
+			return fmt.Sprintf("%s takes no params", opt.Name)
+		}
+		return ""
+	}
+	if !isValidTag(opt.Name) {
+		return fmt.Sprintf("invalid validator name %q", opt.Name)
+	}
+	if vp := sv.compileValidator(opt.Spec(), ""); vp.kind != unknownValidator {
+		return ""
+	}
+	switch {
+	case sv.takesParams(opt.Name) && opt.Params == nil:
+		return fmt.Sprintf("%s requires params", opt.Name)
+	case sv.takesParams(opt.Name):
+		return fmt.Sprintf("malformed params for %s", opt.Name)
+	case opt.Params != nil && sv.isRegistered(opt.Name):
+		return fmt.Sprintf("%s takes no params", opt.Name)
+	}
+	return fmt.Sprintf("unknown validator %s", opt.Name)
+}
+
+// takesParams reports whether name is a validator configured by params, such as length or eqfield.
+func (sv *StructValidator) takesParams(name string) bool {
+	_, param := sv.paramTagMap[name]
+	_, interfaceParam := sv.interfaceParamTagMap[name]
+	return param || interfaceParam ||
+		crossFieldTagRegexp.MatchString(name+"(_)") || conditionalRequiredTagRegexp.MatchString(name+"(_)")
+}
+
+// isRegistered reports whether name is a validator without params.
+func (sv *StructValidator) isRegistered(name string) bool {
+	_, ok := sv.tagMap[name]
+	if !ok {
+		_, ok = sv.customTypeTagMap.GetCtx(name)
+	}
+	return ok
+}
+
+// rawTagOption is an option of a tag before parsing, trimmed of spaces.
+type rawTagOption struct {
+	text   string
+	column int
+}
+
+// splitTagOptions splits tag at the commas that separate options, that is the commas outside of
+// parentheses and quotes, or ending a message. Unterminated quotes and parentheses are reported,
+// and the options having them are left out.
+func splitTagOptions(tag string) ([]rawTagOption, Errors) {
+	var options []rawTagOption
+	var errs Errors
+	start, depth, open := 0, 0, 0
+	// quote is the position of the opening quote while in a quoted value, or -1
+	quote := -1
+	// atValue is set when the next character starts a param or a message, where quotes are allowed
+	inMessage, atValue := false, false
+	flush := func(end int) {
+		text := strings.TrimLeft(tag[start:end], " ")
+		column := end - len(text) + 1
+		text = strings.TrimRight(text, " ")
+		switch {
+		case quote >= 0:
+			errs = append(errs, &TagError{quote + 1, "unterminated quote"})
+		case depth > 0:
+			errs = append(errs, &TagError{open + 1, "missing closing parenthesis"})
+		default:
+			options = append(options, rawTagOption{text, column})
+		}
+	}
+	for i := 0; i < len(tag); i++ {
+		c := tag[i]
+		if quote >= 0 {
+			if c == '\\' {
+				i++
+			} else if c == '\'' {
+				quote = -1
+			}
+			continue
+		}
+		wasAtValue := atValue
+		atValue = false
+		switch {
+		case c == '\\':
+			i++
+		case c == '\'' && wasAtValue:
+			quote = i
+		case c == ',' && (depth == 0 || inMessage):
+			flush(i)
+			start, depth, inMessage = i+1, 0, false
+		case inMessage:
+		case c == '(':
+			if depth == 0 {
+				open = i
+			}
+			depth++
+			atValue = depth == 1
+		case c == ')' && depth > 0:
+			depth--
+		case c == '|' && depth == 1:
+			atValue = true
+		case c == '~' && depth == 0:
+			inMessage, atValue = true, true
+		}
+	}
+	flush(len(tag))
+	return options, errs
+}
+
+// parseTagOption parses an option split by splitTagOptions. groups is the range of the groups
+// suffix in text, so it can be removed.
+func parseTagOption(text string, column int) (opt TagOption, groups [2]int, err error) {
+	opt.Column = column
+	i := 0
+	if strings.HasPrefix(text, "!") {
+		opt.Negated = true
+		i++
+	}
+	end := strings.IndexAny(text[i:], "(@~")
+	if end < 0 {
+		end = len(text)
+	} else {
+		end += i
+	}
+	opt.Name = strings.TrimSpace(text[i:end])
+	if opt.Name == "" {
+		return opt, groups, &TagError{column + i, "missing validator name"}
+	}
+	i = end
+	if i < len(text) && text[i] == '(' {
+		if opt.Params, i, err = parseTagParams(text, i, column); err != nil {
+			return opt, groups, err
+		}
+	}
+	groups[0] = i
+	for i < len(text) && text[i] == '@' {
+		j := i + 1
+		for j < len(text) && isGroupChar(text[j]) {
+			j++
+		}
+		if j == i+1 {
+			return opt, groups, &TagError{column + i, "missing group name"}
+		}
+		opt.Groups = append(opt.Groups, text[i+1:j])
+		i = j
+	}
+	groups[1] = i
+	if i < len(text) && text[i] == '~' {
+		var ok bool
+		if opt.Message, ok = decodeTagValue(text[i+1:]); !ok {
+			return opt, groups, &TagError{column + i + 1, "unexpected text after quoted message"}
+		}
+		i = len(text)
+	}
+	if i < len(text) {
+		return opt, groups, &TagError{column + i, fmt.Sprintf("unexpected %q", text[i:])}
+	}
+	return opt, groups, nil
+}
+
+// parseTagParams parses the params starting at the parenthesis text[open], and returns the
+// position following the closing parenthesis. Params are split at the `|` outside of nested
+// parentheses, so `matches(^(a|b)$)` has a single param.
+func parseTagParams(text string, open int, column int) ([]string, int, error) {
+	params := []string{}
+	start, depth := open+1, 0
+	add := func(end int) error {
+		param, ok := decodeTagValue(text[start:end])
+		if !ok {
+			return &TagError{column + start, "unexpected text after quoted param"}
+		}
+		params = append(params, param)
+		return nil
+	}
+	for i := open; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\\':
+			i++
+		case c == '\'' && i == start:
+			i = closingQuote(text, i)
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return params, i + 1, add(i)
+			}
+		case c == '|' && depth == 1:
+			if err := add(i); err != nil {
+				return nil, i, err
+			}
+			start = i + 1
+		}
+	}
+	return nil, len(text), &TagError{column + open, "missing closing parenthesis"}
+}
+
+// closingQuote returns the position of the quote closing the one at text[open], or len(text).
+func closingQuote(text string, open int) int {
+	for i := open + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '\'':
+			return i
+		}
+	}
+	return len(text)
+}
+
+// decodeTagValue returns the param or message written as v, removing its quotes and escapes.
+// ok is false when a quoted value is followed by other characters.
+func decodeTagValue(v string) (string, bool) {
+	if strings.HasPrefix(v, "'") {
+		end := closingQuote(v, 0)
+		if end != len(v)-1 {
+			return v, false
+		}
+		return strings.NewReplacer(`\'`, `'`, `\\`, `\`).Replace(v[1:end]), true
+	}
+	return strings.NewReplacer(`\,`, `,`, `\~`, `~`, `\'`, `'`).Replace(v), true
+}
+
+func isGroupChar(c byte) bool {
+	return c == '_' || c == '-' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
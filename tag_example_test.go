@@ -0,0 +1,16 @@
+package govalidator
+
+import "fmt"
+
+func ExampleParseTag() {
+	options, err := ParseTag("required~'Name, please',length(1|10)@create,emial")
+	for _, option := range options {
+		fmt.Printf("%d %s %v %q\n", option.Column, option.Spec(), option.Groups, option.Message)
+	}
+	fmt.Println(err)
+	// Output:
+	// 1 required [] "Name, please"
+	// 25 length(1|10) [create] ""
+	// 45 emial [] ""
+	// column 45: unknown validator emial
+}
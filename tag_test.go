@@ -0,0 +1,145 @@
+package govalidator
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		tag      string
+		expected []TagOption
+	}{
+		{"", nil},
+		{"-", nil},
+		{"required,email", []TagOption{{Name: "required", Column: 1}, {Name: "email", Column: 10}}},
+		{" required , !alpha", []TagOption{{Name: "required", Column: 2}, {Name: "alpha", Negated: true, Column: 13}}},
+		{"length(1|10)@create@update~Bad length", []TagOption{
+			{Name: "length", Params: []string{"1", "10"}, Groups: []string{"create", "update"}, Message: "Bad length", Column: 1},
+		}},
+		{"matches(^[a-z]{1,3}$),required", []TagOption{
+			{Name: "matches", Params: []string{"^[a-z]{1,3}$"}, Column: 1}, {Name: "required", Column: 23},
+		}},
+		{"matches(^(a|b)\\d$)", []TagOption{{Name: "matches", Params: []string{"^(a|b)\\d$"}, Column: 1}}},
+		{"in('a,b'|'it\\'s'|c)", []TagOption{{Name: "in", Params: []string{"a,b", "it's", "c"}, Column: 1}}},
+		{"required~'Name, please',email~no\\, not this (one)", []TagOption{
+			{Name: "required", Message: "Name, please", Column: 1},
+			{Name: "email", Message: "no, not this (one)", Column: 25},
+		}},
+		{"required~can't be empty", []TagOption{{Name: "required", Message: "can't be empty", Column: 1}}},
+		{"dive,keys,alpha,endkeys,alpha", []TagOption{
+			{Name: "dive", Column: 1}, {Name: "keys", Column: 6}, {Name: "alpha", Column: 11},
+			{Name: "endkeys", Column: 17}, {Name: "alpha", Column: 25},
+		}},
+	}
+	for _, test := range tests {
+		actual, err := ParseTag(test.tag)
+		if err != nil {
+			t.Errorf("Expected ParseTag(%q) to succeed, got %v", test.tag, err)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected ParseTag(%q) to be %+v, got %+v", test.tag, test.expected, actual)
+		}
+	}
+}
+
+func TestParseTagErrors(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		tag      string
+		expected string
+	}{
+		{"required,emial", "column 10: unknown validator emial"},
+		{"length(a|b)", "column 1: malformed params for length"},
+		{"length", "column 1: length requires params"},
+		{"email(1)", "column 1: email takes no params"},
+		{"required(1)", "column 1: required takes no params"},
+		{"email,required,email", "column 16: duplicate option email, first at column 1"},
+		{"dive,email,dive,email", ""},
+		{"required,,email", "column 10: empty option"},
+		{"length(1|2", "column 7: missing closing parenthesis"},
+		{"in('a|b)", "column 4: unterminated quote"},
+		{"in('a'b)", "column 4: unexpected text after quoted param"},
+		{"required~'Hi' there", "column 10: unexpected text after quoted message"},
+		{"length(1|2)x", "column 12: unexpected \"x\""},
+		{"required@", "column 9: missing group name"},
+		{"!", "column 2: missing validator name"},
+		{"eqfield(Name),required_if(Kind|a),emial,rsapub(x)", "column 35: unknown validator emial;column 41: malformed params for rsapub"},
+	}
+	for _, test := range tests {
+		_, err := ParseTag(test.tag)
+		actual := ""
+		if err != nil {
+			actual = err.Error()
+		}
+		if actual != test.expected {
+			t.Errorf("Expected ParseTag(%q) to fail with %q, got %q", test.tag, test.expected, actual)
+		}
+	}
+}
+
+func TestValidateStructTagQuoting(t *testing.T) {
+	t.Parallel()
+
+	type Quoted struct {
+		Code  string `valid:"matches(^[a-z]{1,3}$),required"`
+		Kind  string `valid:"in('a,b'|c)"`
+		Email string `valid:"email~'Please, a valid email'"`
+		Alt   string `valid:"matches(^foo$|^bar$),required_if(Kind|'a,b')"`
+	}
+
+	var tests = []struct {
+		param    Quoted
+		expected map[string]string
+	}{
+		{Quoted{"abc", "a,b", "me@example.com", "bar"}, map[string]string{}},
+		{Quoted{"abcd", "b", "nope", "a"}, map[string]string{
+			"Code":  "abcd does not validate as matches(^[a-z]{1,3}$)",
+			"Kind":  "b does not validate as in(a,b|c)",
+			"Email": "Please, a valid email",
+			"Alt":   "a does not validate as matches(^foo$|^bar$)",
+		}},
+		{Quoted{"abc", "a,b", "", ""}, map[string]string{"Alt": "non zero value required by required_if(Kind|a,b)"}},
+		{Quoted{}, map[string]string{"Code": "non zero value required"}},
+	}
+	for _, test := range tests {
+		_, err := ValidateStruct(test.param)
+		if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected ValidateStruct(%+v) to report %v, got %v", test.param, test.expected, actual)
+		}
+	}
+}
+
+func TestValidateStructParamCaptures(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.AddParamValidator("between", regexp.MustCompile(`^between\((\w+)-(\w+)\)$`), func(str string, params ...string) bool {
+		return len(params) == 2 && params[0] <= str && str <= params[1]
+	})
+	type Captures struct {
+		Letter string `valid:"between(b-d)"`
+		Word   string `valid:"matches(^foo$|^bar$)"`
+	}
+
+	var tests = []struct {
+		param    Captures
+		expected map[string]string
+	}{
+		{Captures{"c", "bar"}, map[string]string{}},
+		{Captures{"e", "baz"}, map[string]string{
+			"Letter": "e does not validate as between(b-d)",
+			"Word":   "baz does not validate as matches(^foo$|^bar$)",
+		}},
+	}
+	for _, test := range tests {
+		_, err := v.ValidateStruct(test.param)
+		if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Expected ValidateStruct(%+v) to report %v, got %v", test.param, test.expected, actual)
+		}
+	}
+}
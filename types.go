@@ -1,6 +1,7 @@
 package govalidator
 
 import (
+	"context"
 	"reflect"
 	"regexp"
 	"sort"
@@ -14,6 +15,10 @@ type Validator func(str string) bool
 // The second parameter should be the context (in the case of validating a struct: the whole object being validated).
 type CustomTypeValidator func(i interface{}, o interface{}) bool
 
+// CustomTypeValidatorCtx is a CustomTypeValidator that also receives the context passed to
+// ValidateStructCtx or ValidateMapCtx (context.Background() for the functions without Ctx suffix).
+type CustomTypeValidatorCtx func(ctx context.Context, i interface{}, o interface{}) bool
+
 // ParamValidator is a wrapper for validator functions that accept additional parameters.
 type ParamValidator func(str string, params ...string) bool
 
@@ -51,12 +56,20 @@ type stringValues []reflect.Value
 
 // InterfaceParamTagMap is a map of functions accept variants parameters for an interface value
 var InterfaceParamTagMap = map[string]InterfaceParamValidator{
-	"type": IsType,
+	"type":     IsType,
+	"minitems": MinItems,
+	"maxitems": MaxItems,
+	"items":    ItemsLength,
+	"unique":   IsUnique,
 }
 
 // InterfaceParamTagRegexMap maps interface param tags to their respective regexes.
 var InterfaceParamTagRegexMap = map[string]*regexp.Regexp{
-	"type": regexp.MustCompile(`^type\((.*)\)$`),
+	"type":     regexp.MustCompile(`^type\((.*)\)$`),
+	"minitems": regexp.MustCompile(`^minitems\((\d+)\)$`),
+	"maxitems": regexp.MustCompile(`^maxitems\((\d+)\)$`),
+	"items":    regexp.MustCompile(`^items\((\d+)\|(\d+)\)$`),
+	"unique":   regexp.MustCompile(`^unique$`),
 }
 
 // ParamTagMap is a map of functions accept variants parameters
@@ -86,7 +99,10 @@ var ParamTagRegexMap = map[string]*regexp.Regexp{
 }
 
 type customTypeTagMap struct {
-	validators map[string]CustomTypeValidator
+	validators    map[string]CustomTypeValidator
+	ctxValidators map[string]CustomTypeValidatorCtx
+	// registrations counts the calls to Set and SetCtx, so the compiled plans are dropped on changes
+	registrations int64
 
 	sync.RWMutex
 }
@@ -94,14 +110,62 @@ type customTypeTagMap struct {
 func (tm *customTypeTagMap) Get(name string) (CustomTypeValidator, bool) {
 	tm.RLock()
 	defer tm.RUnlock()
-	v, ok := tm.validators[name]
-	return v, ok
+	if v, ok := tm.validators[name]; ok {
+		return v, ok
+	}
+	if v, ok := tm.ctxValidators[name]; ok {
+		return func(i interface{}, o interface{}) bool {
+			return v(context.Background(), i, o)
+		}, true
+	}
+	return nil, false
+}
+
+// GetCtx returns the validator registered under name by either Set or SetCtx.
+func (tm *customTypeTagMap) GetCtx(name string) (CustomTypeValidatorCtx, bool) {
+	tm.RLock()
+	defer tm.RUnlock()
+	if v, ok := tm.ctxValidators[name]; ok {
+		return v, ok
+	}
+	if v, ok := tm.validators[name]; ok {
+		return func(_ context.Context, i interface{}, o interface{}) bool {
+			return v(i, o)
+		}, true
+	}
+	return nil, false
+}
+
+func (tm *customTypeTagMap) Len() int {
+	tm.RLock()
+	defer tm.RUnlock()
+	return len(tm.validators) + len(tm.ctxValidators)
+}
+
+func (tm *customTypeTagMap) Registrations() int64 {
+	tm.RLock()
+	defer tm.RUnlock()
+	return tm.registrations
 }
 
 func (tm *customTypeTagMap) Set(name string, ctv CustomTypeValidator) {
 	tm.Lock()
 	defer tm.Unlock()
+	delete(tm.ctxValidators, name)
 	tm.validators[name] = ctv
+	tm.registrations++
+}
+
+// SetCtx registers a context-aware validator, replacing any validator registered under name.
+func (tm *customTypeTagMap) SetCtx(name string, ctv CustomTypeValidatorCtx) {
+	tm.Lock()
+	defer tm.Unlock()
+	delete(tm.validators, name)
+	if tm.ctxValidators == nil {
+		tm.ctxValidators = make(map[string]CustomTypeValidatorCtx)
+	}
+	tm.ctxValidators[name] = ctv
+	tm.registrations++
 }
 
 // CustomTypeTagMap is a map of functions that can be used as tags for ValidateStruct function.
@@ -0,0 +1,107 @@
+package govalidator
+
+import (
+	"context"
+	"reflect"
+)
+
+// Validatable is implemented by types checking invariants that can't be expressed with tags,
+// e.g. that at least one of several fields is set. ValidateStruct calls ValidateStructLevel after
+// the tag validation of the struct, for the struct itself as well as for nested structs and slice
+// elements. The method is not named Validate, so the common Validate methods calling ValidateStruct
+// on their receiver are not called back. A hook validating its receiver again must implement
+// ValidatableCtx and pass its context to ValidateStructCtx, so that it isn't called again.
+type Validatable interface {
+	ValidateStructLevel() error
+}
+
+// ValidatableCtx is the context-aware variant of Validatable. It is preferred over ValidateStructLevel
+// when a type implements both, and receives the context passed to ValidateStructCtx. Validating
+// the receiver with ValidateStructCtx(ctx, ...) doesn't call ValidateStructLevelCtx again.
+type ValidatableCtx interface {
+	ValidateStructLevelCtx(ctx context.Context) error
+}
+
+var (
+	validatableType    = reflect.TypeOf((*Validatable)(nil)).Elem()
+	validatableCtxType = reflect.TypeOf((*ValidatableCtx)(nil)).Elem()
+)
+
+// hookKey identifies the struct a hook is called for by its type and address.
+type hookKey struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+// runningHookContextKey holds the runningHook list of the hooks running in the current validation,
+// so that a hook validating its receiver with the context it was given is not called again, which
+// would never return. Concurrent validations have their own lists.
+type runningHookContextKey struct{}
+
+type runningHook struct {
+	key    hookKey
+	parent *runningHook
+}
+
+// isHookRunning reports whether the hook of key is running in the validation of ctx.
+func isHookRunning(ctx context.Context, key hookKey) bool {
+	for h, _ := ctx.Value(runningHookContextKey{}).(*runningHook); h != nil; h = h.parent {
+		if h.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// validateHook calls the ValidateStructLevel or ValidateStructLevelCtx method of the struct val,
+// if it has one and it isn't already running for val.
+// Errors that are not Error are wrapped in an Error with an empty name, so that they are
+// reported at the path of the struct.
+func (p *structPlan) validateHook(ctx context.Context, val reflect.Value) error {
+	if !p.validatable && !p.validatableCtx {
+		return nil
+	}
+	if val = readableValue(val); !val.CanInterface() {
+		// not called for the structs read from unexported fields that can't be copied
+		return nil
+	}
+	var ptr reflect.Value
+	if val.CanAddr() {
+		ptr = val.Addr()
+	} else {
+		// the methods may have pointer receivers
+		ptr = reflect.New(val.Type())
+		ptr.Elem().Set(val)
+	}
+	key := hookKey{typ: val.Type(), addr: ptr.Pointer()}
+	if isHookRunning(ctx, key) {
+		return nil
+	}
+	parent, _ := ctx.Value(runningHookContextKey{}).(*runningHook)
+	ctx = context.WithValue(ctx, runningHookContextKey{}, &runningHook{key, parent})
+
+	var err error
+	if p.validatableCtx {
+		err = ptr.Interface().(ValidatableCtx).ValidateStructLevelCtx(ctx)
+	} else {
+		err = ptr.Interface().(Validatable).ValidateStructLevel()
+	}
+	if err == nil {
+		return nil
+	}
+	return wrapHookErrors(err)
+}
+
+func wrapHookErrors(err error) error {
+	switch err2 := err.(type) {
+	case Error:
+		return err2
+	case Errors:
+		errs := make(Errors, len(err2))
+		for i, err3 := range err2 {
+			errs[i] = wrapHookErrors(err3)
+		}
+		return errs
+	}
+	return Error{Err: err, Validator: "validate", Path: []string{}, Code: CodeValidate}
+}
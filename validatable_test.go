@@ -0,0 +1,171 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type validatableRange struct {
+	From int `valid:"range(0|100)" json:"from"`
+	To   int `valid:"range(0|100)" json:"to"`
+}
+
+func (r validatableRange) ValidateStructLevel() error {
+	if r.From > r.To {
+		return fmt.Errorf("from must not be after to")
+	}
+	return nil
+}
+
+type validatableAccount struct {
+	Email string `valid:"email"`
+	Phone string
+}
+
+func (a *validatableAccount) ValidateStructLevelCtx(ctx context.Context) error {
+	if a.Email == "" && a.Phone == "" {
+		return Errors{
+			Error{Name: "Email", Err: fmt.Errorf("email or phone required"), Code: CodeRequired},
+			Error{Name: "Phone", Err: fmt.Errorf("email or phone required"), Code: CodeRequired},
+		}
+	}
+	if ctx.Value(validatableContextKey{}) != nil {
+		return errors.New("context value found")
+	}
+	return nil
+}
+
+// ValidateStructLevel is ignored as ValidateStructLevelCtx is implemented too.
+func (a *validatableAccount) ValidateStructLevel() error {
+	return errors.New("Validate called")
+}
+
+type validatableContextKey struct{}
+
+func TestValidatable(t *testing.T) {
+	t.Parallel()
+
+	type Booking struct {
+		Range    validatableRange    `json:"range"`
+		Ranges   []validatableRange  `json:"ranges"`
+		Account  *validatableAccount `json:"account"`
+		Accounts []validatableAccount
+	}
+
+	var tests = []struct {
+		param    interface{}
+		expected map[string]string
+	}{
+		{validatableRange{From: 1, To: 2}, map[string]string{}},
+		{validatableRange{From: 3, To: 2}, map[string]string{"": "from must not be after to"}},
+		{&validatableRange{From: 300, To: 2}, map[string]string{
+			"from": "300 does not validate as range(0|100)",
+			"":     "from must not be after to",
+		}},
+		{Booking{
+			Range:    validatableRange{From: 3, To: 2},
+			Ranges:   []validatableRange{{From: 1, To: 2}, {From: 5, To: 4}},
+			Account:  &validatableAccount{},
+			Accounts: []validatableAccount{{Email: "a@b.c"}, {}},
+		}, map[string]string{
+			"range":            "from must not be after to",
+			"ranges.1":         "from must not be after to",
+			"account.Email":    "email or phone required",
+			"account.Phone":    "email or phone required",
+			"Accounts.1.Email": "email or phone required",
+			"Accounts.1.Phone": "email or phone required",
+		}},
+	}
+	for _, test := range tests {
+		ok, err := ValidateStruct(test.param)
+		if actual := ErrorsByPath(err); fmt.Sprint(actual) != fmt.Sprint(test.expected) || ok != (len(test.expected) == 0) {
+			t.Errorf("Expected ValidateStruct(%+v) to report %v, got %v %v", test.param, test.expected, ok, actual)
+		}
+	}
+
+	ctx := context.WithValue(context.Background(), validatableContextKey{}, true)
+	_, err := ValidateStructCtx(ctx, &validatableAccount{Phone: "1"})
+	if err == nil || err.Error() != "context value found" {
+		t.Errorf("Expected ValidateStructLevelCtx to receive the context, got %v", err)
+	}
+
+	_, err = ValidateStruct(validatableRange{From: 3, To: 2})
+	var e Error
+	if !errors.As(err, &e) || e.Code != CodeValidate {
+		t.Errorf("Expected the error of ValidateStructLevel to be wrapped in an Error, got %#v", err)
+	}
+}
+
+type validatableUser struct {
+	Name  string `valid:"required"`
+	calls int
+}
+
+// Validate is not a hook: it would never return if ValidateStruct called it.
+func (u *validatableUser) Validate() error {
+	_, err := ValidateStruct(u)
+	return err
+}
+
+// ValidateStructLevelCtx validates its receiver too, which doesn't call it again.
+func (u *validatableUser) ValidateStructLevelCtx(ctx context.Context) error {
+	u.calls++
+	if ok, _ := ValidateStructCtx(ctx, u); !ok {
+		// the tag errors are reported by the outer call
+		return nil
+	}
+	if u.Name == "root" {
+		return errors.New("root is reserved")
+	}
+	return nil
+}
+
+func TestValidatableRecursion(t *testing.T) {
+	t.Parallel()
+
+	u := &validatableUser{Name: "root"}
+	if err := u.Validate(); err == nil || err.Error() != "root is reserved" {
+		t.Errorf("Expected Validate to report the error of ValidateStructLevelCtx, got %v", err)
+	}
+	if u.calls != 1 {
+		t.Errorf("Expected ValidateStructLevelCtx to be called once, got %d calls", u.calls)
+	}
+
+	u = &validatableUser{}
+	if err := u.Validate(); err == nil || err.Error() != "Name: non zero value required" {
+		t.Errorf("Expected Validate to report the tag errors once, got %v", err)
+	}
+}
+
+type failingHook struct {
+	calls atomic.Int32
+}
+
+func (h *failingHook) ValidateStructLevel() error {
+	h.calls.Add(1)
+	return errors.New("always invalid")
+}
+
+func TestValidatableConcurrent(t *testing.T) {
+	t.Parallel()
+
+	h := &failingHook{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := ValidateStruct(h); ok {
+				t.Error("Expected every concurrent validation to call the hook")
+			}
+		}()
+	}
+	wg.Wait()
+	if calls := h.calls.Load(); calls != 8 {
+		t.Errorf("Expected ValidateStructLevel to be called 8 times, got %d", calls)
+	}
+}
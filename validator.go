@@ -3,6 +3,7 @@ package govalidator
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
@@ -23,12 +24,10 @@ import (
 )
 
 var (
-	fieldsRequiredByDefault bool
-	nilPtrAllowedByRequired = false
-	notNumberRegexp         = regexp.MustCompile("[^0-9]+")
-	whiteSpacesAndMinus     = regexp.MustCompile(`[\s-]+`)
-	paramsRegexp            = regexp.MustCompile(`\(.*\)$`)
-	rxJWT                   = regexp.MustCompile(`^[A-Za-z0-9-_]+\.[A-Za-z0-9-_]+\.[A-Za-z0-9-_]+$`)
+	notNumberRegexp     = regexp.MustCompile("[^0-9]+")
+	whiteSpacesAndMinus = regexp.MustCompile(`[\s-]+`)
+	paramsRegexp        = regexp.MustCompile(`\(.*\)$`)
+	rxJWT               = regexp.MustCompile(`^[A-Za-z0-9-_]+\.[A-Za-z0-9-_]+\.[A-Za-z0-9-_]+$`)
 )
 
 const maxURLRuneCount = 2083
@@ -55,7 +54,7 @@ const rfc3339WithoutZone = "2006-01-02T15:04:05"
 //	    Name  string `valid:"-"`
 //	    Email string `valid:"email,optional"`
 func SetFieldsRequiredByDefault(value bool) {
-	fieldsRequiredByDefault = value
+	defaultValidator.fieldsRequiredByDefault = value
 }
 
 // SetNilPtrAllowedByRequired causes validation to pass for nil ptrs when a field is set to required.
@@ -68,7 +67,38 @@ func SetFieldsRequiredByDefault(value bool) {
 // With `Name` set to nil, this will be considered valid by validation.
 // By default this is disabled.
 func SetNilPtrAllowedByRequired(value bool) {
-	nilPtrAllowedByRequired = value
+	defaultValidator.nilPtrAllowedByRequired = value
+}
+
+// SetAllErrors causes validation to run every validator of a field instead of stopping at the
+// first failing one. The failures of a field are then reported together as Errors, e.g.
+//
+//	type exampleStruct struct {
+//	    Login string `valid:"stringlength(4|32),alphanum,lowercase"`
+//
+// With `Login` set to "A-B", stringlength, alphanum and lowercase are all reported.
+// By default this is disabled.
+func SetAllErrors(value bool) {
+	defaultValidator.allErrors = value
+}
+
+// SetUnexportedFields causes the unexported fields of structs to be validated too.
+// By default they are skipped, but the exported fields of unexported embedded
+// structs are validated in any case.
+func SetUnexportedFields(value bool) {
+	defaultValidator.unexportedFields = value
+}
+
+// SetRedactedValues causes validation errors not to carry the offending values in Error.Value
+// and in their messages, e.g. to keep secrets out of logs and API responses. A single field can be redacted with the
+// `redact` option instead:
+//
+//	type exampleStruct struct {
+//	    Password string `valid:"stringlength(8|64),redact"`
+//
+// By default this is disabled.
+func SetRedactedValues(value bool) {
+	defaultValidator.redactedValues = value
 }
 
 // IsEmail checks if the string is an email.
@@ -1029,15 +1059,100 @@ func toJSONName(tag string) string {
 	return name
 }
 
-func prependPathToErrors(err error, path string) error {
+// prependPathToErrors prepends the path segments, e.g. "Items" and "0", to the Path of err.
+func prependPathToErrors(err error, path ...string) error {
 	switch err2 := err.(type) {
 	case Error:
-		err2.Path = append([]string{path}, err2.Path...)
+		err2.Path = append(append([]string{}, path...), err2.Path...)
 		return err2
 	case Errors:
 		errors := err2.Errors()
 		for i, err3 := range errors {
-			errors[i] = prependPathToErrors(err3, path)
+			errors[i] = prependPathToErrors(err3, path...)
+		}
+		return err2
+	}
+	return err
+}
+
+// elementErrors sets the path of the errors reported for the collection element validated by ef,
+// e.g. ["tags", "1"], keeping the name of the field. The errors of the elements of nested
+// collections and of nested structs already have their path.
+func elementErrors(err error, ef *fieldPlan) error {
+	switch err2 := err.(type) {
+	case Error:
+		if err2.element || len(err2.Path) > 0 || err2.Name != ef.name {
+			return err2
+		}
+		err2.Path, err2.element = ef.path(), true
+		return err2
+	case Errors:
+		for i, err3 := range err2 {
+			err2[i] = elementErrors(err3, ef)
+		}
+		return err2
+	}
+	return err
+}
+
+// renameErrors replaces the field name from with to in the names of err.
+func renameErrors(err error, from, to string) error {
+	switch err2 := err.(type) {
+	case Error:
+		if err2.Name == from {
+			err2.Name = to
+		}
+		return err2
+	case Errors:
+		for i, err3 := range err2 {
+			err2[i] = renameErrors(err3, from, to)
+		}
+		return err2
+	}
+	return err
+}
+
+// sortedKeys returns the keys of m in sorted order, so that maps are validated deterministically.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// label returns the text standing for the value of f in messages when it is redacted, see formatValue.
+func (f *fieldPlan) label() string {
+	if name := f.pathName(); name != "" {
+		return name
+	}
+	return "value"
+}
+
+// redactedContextKey marks the context of a field having the redact option, so that the errors
+// of its elements and nested fields are built without the values too.
+type redactedContextKey struct{}
+
+// formatValue returns the text standing for the value v in the messages of the errors of f:
+// the value itself, or the name of the field when the value is redacted.
+func (sv *StructValidator) formatValue(ctx context.Context, f *fieldPlan, v reflect.Value) string {
+	if redacted, _ := ctx.Value(redactedContextKey{}).(bool); !redacted && !sv.isRedacted(f.tagPlan) {
+		return fmt.Sprint(v)
+	}
+	return f.label()
+}
+
+// redactErrors clears the offending values held by err. Their messages are built
+// without the values by formatValue.
+func redactErrors(err error) error {
+	switch err2 := err.(type) {
+	case Error:
+		err2.Value = nil
+		return err2
+	case Errors:
+		for i, err3 := range err2 {
+			err2[i] = redactErrors(err3)
 		}
 		return err2
 	}
@@ -1056,15 +1171,41 @@ func ValidateArray(array []interface{}, iterator ConditionIterator) bool {
 //
 //	map[string]interface{}{"name":"required,alpha","address":map[string]interface{}{"line1":"required,alphanum"}}
 func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, error) {
+	return defaultValidator.ValidateMap(s, m)
+}
+
+// ValidateMapCtx is ValidateMap with a context that is passed to CustomTypeValidatorCtx validators.
+// Validation stops early and returns ctx.Err() once the context is done.
+func ValidateMapCtx(ctx context.Context, s map[string]interface{}, m map[string]interface{}) (bool, error) {
+	return defaultValidator.ValidateMapCtx(ctx, s, m)
+}
+
+// ValidateMap validates the map s against the validation map m using the tags registered on sv.
+// See the package-level ValidateMap for the format of m.
+func (sv *StructValidator) ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, error) {
+	return sv.ValidateMapCtx(context.Background(), s, m)
+}
+
+// ValidateMapCtx is the context-aware variant of ValidateMap.
+func (sv *StructValidator) ValidateMapCtx(ctx context.Context, s map[string]interface{}, m map[string]interface{}) (bool, error) {
+	result, err := sv.validateMap(ctx, s, m)
+	return result, sv.translateErrors(ctx, err)
+}
+
+// validateMap is ValidateMapCtx without the translation of the messages.
+func (sv *StructValidator) validateMap(ctx context.Context, s map[string]interface{}, m map[string]interface{}) (bool, error) {
 	if s == nil {
 		return true, nil
 	}
 	result := true
 	var err error
 	var errs Errors
-	var index int
 	val := reflect.ValueOf(s)
-	for key, value := range s {
+	for _, key := range sortedKeys(s) {
+		value := s[key]
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
 		presentResult := true
 		validator, ok := m[key]
 		if !ok {
@@ -1088,7 +1229,7 @@ func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, erro
 				err = prependPathToErrors(err, key)
 				errs = append(errs, err)
 			} else {
-				mapResult, err = ValidateMap(v, subValidator)
+				mapResult, err = sv.validateMap(ctx, v, subValidator)
 				if err != nil {
 					mapResult = false
 					err = prependPathToErrors(err, key)
@@ -1100,22 +1241,21 @@ func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, erro
 				(valueField.Kind() == reflect.Ptr && valueField.Elem().Kind() == reflect.Struct)) &&
 				subValidator != "-" {
 				var err error
-				structResult, err = ValidateStruct(valueField.Interface())
+				structResult, err = sv.validateStruct(ctx, valueField.Interface())
 				if err != nil {
 					err = prependPathToErrors(err, key)
 					errs = append(errs, err)
 				}
 			}
-			resultField, err = typeCheck(valueField, reflect.StructField{
-				Name:      key,
-				PkgPath:   "",
-				Type:      val.Type(),
-				Tag:       reflect.StructTag(fmt.Sprintf("%s:%q", tagName, subValidator)),
-				Offset:    0,
-				Index:     []int{index},
-				Anonymous: false,
+			plan := sv.tagPlan(subValidator, groupsFromContext(ctx))
+			resultField, err = sv.typeCheck(ctx, valueField, &fieldPlan{
+				tagPlan: plan,
+				name:    key,
 			}, val, nil)
 			if err != nil {
+				if sv.isRedacted(plan) {
+					err = redactErrors(err)
+				}
 				errs = append(errs, err)
 			}
 		case nil:
@@ -1127,23 +1267,29 @@ func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, erro
 			errs = append(errs, err)
 		}
 		result = result && presentResult && typeResult && resultField && structResult && mapResult
-		index++
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
 	}
 	// checks required keys
 	requiredResult := true
-	for key, value := range m {
-		if schema, ok := value.(string); ok {
-			tags := parseTagIntoMap(schema)
-			if required, ok := tags["required"]; ok {
-				if _, ok := s[key]; !ok {
-					requiredResult = false
-					if required.customErrorMessage != "" {
-						err = Error{key, fmt.Errorf(required.customErrorMessage), true, "required", []string{}}
-					} else {
-						err = Error{key, fmt.Errorf("required field missing"), false, "required", []string{}}
-					}
-					errs = append(errs, err)
+	for _, key := range sortedKeys(m) {
+		if schema, ok := m[key].(string); ok {
+			plan := sv.tagPlan(schema, groupsFromContext(ctx))
+			if _, ok := s[key]; ok {
+				continue
+			}
+			if required, ok := plan.options["required"]; ok {
+				requiredResult = false
+				if required.customErrorMessage != "" {
+					err = Error{Name: key, Err: fmt.Errorf(required.customErrorMessage), CustomErrorMessageExists: true, Validator: "required", Path: []string{}, Field: key, Code: CodeRequired}
+				} else {
+					err = Error{Name: key, Err: fmt.Errorf("required field missing"), Validator: "required", Path: []string{}, Field: key, Code: CodeRequired}
 				}
+				errs = append(errs, err)
+			} else if vp, ok := conditionalRequirement(plan, val); ok {
+				requiredResult = false
+				errs = append(errs, conditionalRequiredError(key, vp, "required field missing"))
 			}
 		}
 	}
@@ -1156,14 +1302,40 @@ func ValidateMap(s map[string]interface{}, m map[string]interface{}) (bool, erro
 
 // ValidateStruct use tags for fields.
 // result will be equal to `false` if there are any errors.
-// todo currently there is no guarantee that errors will be returned in predictable order (tests may to fail)
+// Errors are returned in the declaration order of the fields, see ErrorsByPath to look them up.
 func ValidateStruct(s interface{}) (bool, error) {
+	return defaultValidator.ValidateStruct(s)
+}
+
+// ValidateStructCtx is ValidateStruct with a context that is passed to CustomTypeValidatorCtx validators.
+// Validation stops early and returns ctx.Err() once the context is done.
+func ValidateStructCtx(ctx context.Context, s interface{}) (bool, error) {
+	return defaultValidator.ValidateStructCtx(ctx, s)
+}
+
+// ValidateStruct validates s using the tags and policies configured on sv.
+func (sv *StructValidator) ValidateStruct(s interface{}) (bool, error) {
+	return sv.ValidateStructCtx(context.Background(), s)
+}
+
+// ValidateStructCtx is the context-aware variant of ValidateStruct.
+func (sv *StructValidator) ValidateStructCtx(ctx context.Context, s interface{}) (bool, error) {
+	result, err := sv.validateStruct(ctx, s)
+	return result, sv.translateErrors(ctx, err)
+}
+
+// validateStruct is ValidateStructCtx without the translation of the messages,
+// which is done once for the whole result.
+func (sv *StructValidator) validateStruct(ctx context.Context, s interface{}) (bool, error) {
 	if s == nil {
 		return true, nil
 	}
-	result := true
-	var err error
-	val := reflect.ValueOf(s)
+	return sv.validateStructValue(ctx, reflect.ValueOf(s))
+}
+
+// validateStructValue is validateStruct for a struct or a pointer to a struct held by val,
+// which may be read from an unexported field.
+func (sv *StructValidator) validateStructValue(ctx context.Context, val reflect.Value) (bool, error) {
 	if val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
@@ -1171,54 +1343,78 @@ func ValidateStruct(s interface{}) (bool, error) {
 	if val.Kind() != reflect.Struct {
 		return false, fmt.Errorf("function only accepts structs; got %s", val.Kind())
 	}
+	return sv.validateFields(ctx, val, sv.structPlan(val.Type(), groupsFromContext(ctx)))
+}
+
+// validateFields validates the fields of the struct val listed by plan.
+func (sv *StructValidator) validateFields(ctx context.Context, val reflect.Value, plan *structPlan) (bool, error) {
+	result := true
+	var err error
 	var errs Errors
-	for i := 0; i < val.NumField(); i++ {
-		valueField := val.Field(i)
-		typeField := val.Type().Field(i)
-		if typeField.PkgPath != "" {
-			continue // Private field
+	sel := selectionFromContext(ctx)
+	for i := range plan.fields {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		f := &plan.fields[i]
+		// checked is the plan of the options applied to the field itself
+		fieldCtx, checked := ctx, f
+		if sel != nil {
+			var skip, ownOptions bool
+			if fieldCtx, skip, ownOptions = sel.selectField(ctx, f); skip {
+				continue
+			}
+			if !ownOptions {
+				// validates only the selected nested fields
+				checked = &fieldPlan{tagPlan: sv.tagPlan("optional", ""), index: f.index, name: f.name, jsonName: f.jsonName, embedded: f.embedded}
+			}
 		}
+		if _, ok := f.options["redact"]; ok {
+			fieldCtx = context.WithValue(fieldCtx, redactedContextKey{}, true)
+		}
+		valueField := fieldByIndex(val, f.index)
 		structResult := true
 		if valueField.Kind() == reflect.Interface {
 			valueField = valueField.Elem()
 		}
 		if (valueField.Kind() == reflect.Struct ||
 			(valueField.Kind() == reflect.Ptr && valueField.Elem().Kind() == reflect.Struct)) &&
-			typeField.Tag.Get(tagName) != "-" {
+			f.tag != "-" && !f.embedded {
 			var err error
-			structResult, err = ValidateStruct(valueField.Interface())
+			structResult, err = sv.validateStructValue(fieldCtx, valueField)
 			if err != nil {
-				err = prependPathToErrors(err, typeField.Name)
+				err = prependPathToErrors(err, f.pathName())
+				if sv.isRedacted(f.tagPlan) {
+					err = redactErrors(err)
+				}
 				errs = append(errs, err)
 			}
 		}
-		resultField, err2 := typeCheck(valueField, typeField, val, nil)
+		resultField, err2 := sv.typeCheck(fieldCtx, valueField, checked, val, nil)
 		if err2 != nil {
+			if sv.isRedacted(f.tagPlan) {
+				err2 = redactErrors(err2)
+			}
 
 			// Replace structure name with JSON name if there is a tag on the variable
-			jsonTag := toJSONName(typeField.Tag.Get("json"))
-			if jsonTag != "" {
-				switch jsonError := err2.(type) {
-				case Error:
-					jsonError.Name = jsonTag
-					err2 = jsonError
-				case Errors:
-					for i2, err3 := range jsonError {
-						switch customErr := err3.(type) {
-						case Error:
-							customErr.Name = jsonTag
-							jsonError[i2] = customErr
-						}
-					}
-
-					err2 = jsonError
-				}
+			if f.jsonName != "" {
+				err2 = renameErrors(err2, f.name, f.jsonName)
 			}
 
 			errs = append(errs, err2)
 		}
 		result = result && resultField && structResult
 	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	// Validatable is only called for the structs validated as a whole
+	if sel == nil {
+		if err := plan.validateHook(ctx, val); err != nil {
+			result = false
+			errs = append(errs, err)
+		}
+	}
 	if len(errs) > 0 {
 		err = errs
 	}
@@ -1262,22 +1458,17 @@ func ValidateMapAsync(s map[string]interface{}, m map[string]interface{}) (<-cha
 }
 
 // parseTagIntoMap parses a struct tag `valid:required~Some error message,length(2|3)` into map[string]string{"required": "Some error message", "length(2|3)": ""}
+// Malformed options are skipped, see ParseTag to report them.
 func parseTagIntoMap(tag string) tagOptionsMap {
 	optionsMap := make(tagOptionsMap)
-	options := strings.Split(tag, ",")
+	options, _ := splitTagOptions(tag)
 
 	for i, option := range options {
-		option = strings.TrimSpace(option)
-
-		validationOptions := strings.Split(option, "~")
-		if !isValidTag(validationOptions[0]) {
+		opt, _, err := parseTagOption(option.text, option.column)
+		if err != nil || !isValidTag(opt.Name) {
 			continue
 		}
-		if len(validationOptions) == 2 {
-			optionsMap[validationOptions[0]] = tagOption{validationOptions[0], validationOptions[1], i}
-		} else {
-			optionsMap[validationOptions[0]] = tagOption{validationOptions[0], "", i}
-		}
+		optionsMap[opt.Spec()] = tagOption{opt.Spec(), opt.Message, i}
 	}
 	return optionsMap
 }
@@ -1288,10 +1479,10 @@ func isValidTag(s string) bool {
 	}
 	for _, c := range s {
 		switch {
-		case strings.ContainsRune("\\'\"!#$%&()*+-./:<=>?@[]^_{|}~ ", c):
+		case strings.ContainsRune("\\'\"!#$%&()*+-./:<=>?[]^_{|}~ ", c):
 			// Backslash and quote chars are reserved, but
 			// otherwise any punctuation chars are allowed
-			// in a tag name.
+			// in a tag name. @ starts the groups of an option.
 		default:
 			if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
 				return false
@@ -1323,6 +1514,85 @@ func IsType(v interface{}, params ...string) bool {
 	return false
 }
 
+// collectionLength returns the number of items of a slice, array or map (following pointers).
+func collectionLength(in interface{}) (int, bool) {
+	v := indirectValue(reflect.ValueOf(in))
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// MinItems checks if a slice, array or map has at least params[0] items
+func MinItems(in interface{}, params ...string) bool {
+	if len(params) == 1 {
+		length, ok := collectionLength(in)
+		min, _ := ToInt(params[0])
+		return ok && length >= int(min)
+	}
+	return false
+}
+
+// MaxItems checks if a slice, array or map has at most params[0] items
+func MaxItems(in interface{}, params ...string) bool {
+	if len(params) == 1 {
+		length, ok := collectionLength(in)
+		max, _ := ToInt(params[0])
+		return ok && length <= int(max)
+	}
+	return false
+}
+
+// ItemsLength checks if the number of items of a slice, array or map is between params[0] and params[1]
+func ItemsLength(in interface{}, params ...string) bool {
+	if len(params) == 2 {
+		length, ok := collectionLength(in)
+		min, _ := ToInt(params[0])
+		max, _ := ToInt(params[1])
+		return ok && length >= int(min) && length <= int(max)
+	}
+	return false
+}
+
+// IsUnique checks if the items of a slice or array (or the values of a map) are all different
+func IsUnique(in interface{}, params ...string) bool {
+	v := indirectValue(reflect.ValueOf(in))
+	var items []reflect.Value
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			items = append(items, iter.Value())
+		}
+	default:
+		return false
+	}
+
+	if v.Type().Elem().Comparable() && v.Type().Elem().Kind() != reflect.Interface {
+		seen := make(map[interface{}]struct{}, len(items))
+		for _, item := range items {
+			if _, ok := seen[item.Interface()]; ok {
+				return false
+			}
+			seen[item.Interface()] = struct{}{}
+		}
+		return true
+	}
+	for i := range items {
+		for j := i + 1; j < len(items); j++ {
+			if reflect.DeepEqual(items[i].Interface(), items[j].Interface()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // IsTime checks if string is valid according to given format
 func IsTime(str string, format string) bool {
 	_, err := time.Parse(format, str)
@@ -1468,135 +1738,243 @@ func IsIn(str string, params ...string) bool {
 	return false
 }
 
-func checkRequired(v reflect.Value, t reflect.StructField, options tagOptionsMap) (bool, error) {
-	if nilPtrAllowedByRequired {
+func (sv *StructValidator) checkRequired(v reflect.Value, f *fieldPlan, o reflect.Value) (bool, error) {
+	if sv.nilPtrAllowedByRequired {
 		k := v.Kind()
 		if (k == reflect.Ptr || k == reflect.Interface) && v.IsNil() {
 			return true, nil
 		}
 	}
 
-	if requiredOption, isRequired := options["required"]; isRequired {
+	if requiredOption, isRequired := f.options["required"]; isRequired {
 		if len(requiredOption.customErrorMessage) > 0 {
-			return false, Error{t.Name, fmt.Errorf(requiredOption.customErrorMessage), true, "required", []string{}}
+			return false, Error{Name: f.name, Err: fmt.Errorf(requiredOption.customErrorMessage), CustomErrorMessageExists: true, Validator: "required", Path: []string{},
+				Field: f.name, Code: CodeRequired, Value: interfaceOf(v)}
 		}
-		return false, Error{t.Name, fmt.Errorf("non zero value required"), false, "required", []string{}}
-	} else if _, isOptional := options["optional"]; fieldsRequiredByDefault && !isOptional {
-		return false, Error{t.Name, fmt.Errorf("Missing required field"), false, "required", []string{}}
+		return false, Error{Name: f.name, Err: fmt.Errorf("non zero value required"), Validator: "required", Path: []string{},
+			Field: f.name, Code: CodeRequired, Value: interfaceOf(v)}
+	} else if vp, ok := conditionalRequirement(f.tagPlan, o); ok {
+		return false, conditionalRequiredError(f.name, vp, "non zero value required")
+	} else if hasConditionalRequirement(f.tagPlan) {
+		// not required under the current conditions
+		return true, nil
+	} else if _, isOptional := f.options["optional"]; sv.fieldsRequiredByDefault && !isOptional {
+		return false, Error{Name: f.name, Err: fmt.Errorf("Missing required field"), Validator: "required", Path: []string{},
+			Field: f.name, Code: CodeRequired, Value: interfaceOf(v)}
 	}
 	// not required and empty is valid
 	return true, nil
 }
 
-func typeCheck(v reflect.Value, t reflect.StructField, o reflect.Value, options tagOptionsMap) (isValid bool, resultErr error) {
+// collectionCountValidators are the interface param validators checking the number of items of
+// a collection, which are applied to empty collections too.
+var collectionCountValidators = map[string]bool{"minitems": true, "maxitems": true, "items": true}
+
+// checkEmptyValue applies to the empty value v the validators of f that are not skipped for empty
+// values: the comparisons with other fields, so that eqfield(Password) fails for an empty
+// confirmation, and the number of items of collections, so that minitems(1) fails for a nil slice.
+// Nil pointers and interfaces are absent rather than empty, they are not checked.
+func (sv *StructValidator) checkEmptyValue(ctx context.Context, v reflect.Value, f *fieldPlan, o reflect.Value) (bool, error) {
+	if k := v.Kind(); (k == reflect.Ptr || k == reflect.Interface) && v.IsNil() {
+		return true, nil
+	}
+	var errs Errors
+	_, optional := f.options["optional"]
+	for i := range f.validators {
+		vp := &f.validators[i]
+		var err error
+		switch {
+		case vp.kind == crossFieldValidator && !optional:
+			// empty fields are compared too, unless the tag says they may be left out
+			err = checkCrossField(v, o, f.name, vp)
+		case vp.kind == interfaceParamValidator && collectionCountValidators[vp.key]:
+			validatefunc, ok := sv.interfaceParamTagMap[vp.key]
+			if !ok || !v.CanInterface() {
+				continue
+			}
+			if result := validatefunc(v.Interface(), vp.params...); (!result && !vp.negate) || (result && vp.negate) {
+				err = validationError(f.name, vp, sv.formatValue(ctx, f, v), f.label(), interfaceOf(v))
+			}
+		}
+		if err != nil {
+			if !sv.allErrors {
+				return false, err
+			}
+			errs = append(errs, err)
+		}
+	}
+	if f.dive != nil {
+		// length(min|max) placed before dive
+		if err := checkCollection(v, f, make([]bool, len(f.validators))); err != nil {
+			if !sv.allErrors {
+				return false, err
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return false, errs
+	}
+	return true, nil
+}
+
+// validationError builds the error reported when value, formatted as str, doesn't pass the validator vp.
+// label stands for the value in the message serialized by MarshalJSON, see valueMessage.
+func validationError(name string, vp *validatorPlan, str, label string, value interface{}) error {
+	err := Error{Name: name, Validator: vp.name, Path: []string{}, Field: name, Code: vp.code(), Params: vp.errorParams(), Value: value}
+	switch {
+	case len(vp.customErrorMessage) > 0:
+		err.Err, err.CustomErrorMessageExists = valueError(vp.customErrorMessage, str, label, vp.validator), true
+	case vp.negate:
+		err.Err = valueError("%s does validate as %s", str, label, vp.validator)
+	default:
+		err.Err = valueError("%s does not validate as %s", str, label, vp.validator)
+	}
+	return err
+}
+
+// interfaceOf returns the value held by v, or nil when it can't be obtained.
+func interfaceOf(v reflect.Value) interface{} {
+	v = readableValue(v)
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// typeCheck validates v against the field plan f. consumed records which of f.validators
+// could be applied; it is nil for the field itself and shared with the recursive calls
+// for pointers and collection elements.
+// It stops at the first failing validator unless sv collects all errors, in which case
+// the failures are returned as Errors.
+func (sv *StructValidator) typeCheck(ctx context.Context, v reflect.Value, f *fieldPlan, o reflect.Value, consumed []bool) (isValid bool, resultErr error) {
 	if !v.IsValid() {
 		return false, nil
 	}
 
-	tag := t.Tag.Get(tagName)
-
 	// checks if the field should be ignored
-	switch tag {
+	switch f.tag {
 	case "":
 		if v.Kind() != reflect.Slice && v.Kind() != reflect.Map {
-			if !fieldsRequiredByDefault {
+			if !sv.fieldsRequiredByDefault {
 				return true, nil
 			}
-			return false, Error{t.Name, fmt.Errorf("All fields are required to at least have one validation defined"), false, "required", []string{}}
+			return false, Error{Name: f.name, Err: fmt.Errorf("All fields are required to at least have one validation defined"), Validator: "required", Path: []string{},
+				Field: f.name, Code: CodeNoValidator, Value: interfaceOf(v)}
 		}
 	case "-":
 		return true, nil
 	}
 
 	isRootType := false
-	if options == nil {
+	if consumed == nil {
 		isRootType = true
-		options = parseTagIntoMap(tag)
+		consumed = make([]bool, len(f.validators))
+	}
+
+	if !v.CanInterface() {
+		// read from an unexported field: basic values
+		// are copied, collections and structs only for the validators taking them as a whole,
+		// their elements and fields being copied as they are validated
+		switch {
+		case isStringableKind(v.Kind()), v.Kind() == reflect.Bool, isRootType && f.takesValue():
+			v = readableValue(v)
+		}
 	}
 
 	if isEmptyValue(v) {
-		// an empty value is not validated, checks only required
-		isValid, resultErr = checkRequired(v, t, options)
-		for key := range options {
-			delete(options, key)
+		// an empty value is not validated, checks only required, the comparisons with other fields
+		// and the number of items of collections
+		for i := range consumed {
+			consumed[i] = true
+		}
+		if ok, err := sv.checkRequired(v, f, o); !ok || err != nil || !isRootType {
+			return ok, err
 		}
-		return isValid, resultErr
+		return sv.checkEmptyValue(ctx, v, f, o)
 	}
 
-	var customTypeErrors Errors
-	optionsOrder := options.orderedKeys()
-	for _, validatorName := range optionsOrder {
-		validatorStruct := options[validatorName]
-		if validatefunc, ok := CustomTypeTagMap.Get(validatorName); ok {
-			delete(options, validatorName)
+	// errs collects the failures when sv.allErrors is set
+	var errs Errors
+
+	if isRootType {
+		// custom type and interface param validators are applied to the value as a whole
+		var customTypeErrors Errors
+		for i := range f.validators {
+			vp := &f.validators[i]
+			if vp.kind != customTypeValidator {
+				continue
+			}
+			validatefunc, ok := sv.customTypeTagMap.GetCtx(vp.key)
+			if !ok || !v.CanInterface() {
+				// values read from unexported fields that can't be copied are not passed to validators
+				continue
+			}
+			consumed[i] = true
 
-			if result := validatefunc(v.Interface(), o.Interface()); !result {
-				if len(validatorStruct.customErrorMessage) > 0 {
-					customTypeErrors = append(customTypeErrors, Error{Name: t.Name, Err: TruncatingErrorf(validatorStruct.customErrorMessage, fmt.Sprint(v), validatorName), CustomErrorMessageExists: true, Validator: stripParams(validatorName)})
+			if result := validatefunc(ctx, v.Interface(), interfaceOf(o)); !result {
+				if len(vp.customErrorMessage) > 0 {
+					customTypeErrors = append(customTypeErrors, Error{Name: f.name, Err: valueError(vp.customErrorMessage, sv.formatValue(ctx, f, v), f.label(), vp.spec), CustomErrorMessageExists: true, Validator: vp.name,
+						Field: f.name, Code: vp.code(), Value: interfaceOf(v)})
 					continue
 				}
-				customTypeErrors = append(customTypeErrors, Error{Name: t.Name, Err: fmt.Errorf("%s does not validate as %s", fmt.Sprint(v), validatorName), CustomErrorMessageExists: false, Validator: stripParams(validatorName)})
+				customTypeErrors = append(customTypeErrors, Error{Name: f.name, Err: valueError("%s does not validate as %s", sv.formatValue(ctx, f, v), f.label(), vp.spec), CustomErrorMessageExists: false, Validator: vp.name,
+					Field: f.name, Code: vp.code(), Value: interfaceOf(v)})
 			}
 		}
-	}
 
-	if len(customTypeErrors.Errors()) > 0 {
-		return false, customTypeErrors
-	}
+		if len(customTypeErrors.Errors()) > 0 {
+			if !sv.allErrors {
+				return false, customTypeErrors
+			}
+			errs = append(errs, customTypeErrors...)
+		}
 
-	if isRootType {
 		// Ensure that we've checked the value by all specified validators before report that the value is valid
 		defer func() {
-			delete(options, "optional")
-			delete(options, "required")
-
-			if isValid && resultErr == nil && len(options) != 0 {
-				optionsOrder := options.orderedKeys()
-				for _, validator := range optionsOrder {
+			if !isValid || resultErr != nil {
+				return
+			}
+			for i := range f.validators {
+				if !consumed[i] {
 					isValid = false
-					resultErr = Error{t.Name, fmt.Errorf(
-						"The following validator is invalid or can't be applied to the field: %q", validator), false, stripParams(validator), []string{}}
+					resultErr = Error{Name: f.name, Err: fmt.Errorf(
+						"The following validator is invalid or can't be applied to the field: %q", f.validators[i].spec), Validator: f.validators[i].name, Path: []string{},
+						Field: f.name, Code: CodeInvalidValidator, Value: interfaceOf(v)}
 					return
 				}
 			}
 		}()
-	}
-
-	for _, validatorSpec := range optionsOrder {
-		validatorStruct := options[validatorSpec]
-		var negate bool
-		validator := validatorSpec
-		customMsgExists := len(validatorStruct.customErrorMessage) > 0
-
-		// checks whether the tag looks like '!something' or 'something'
-		if validator[0] == '!' {
-			validator = validator[1:]
-			negate = true
-		}
-
-		// checks for interface param validators
-		for key, value := range InterfaceParamTagRegexMap {
-			ps := value.FindStringSubmatch(validator)
-			if len(ps) == 0 {
-				continue
-			}
-
-			validatefunc, ok := InterfaceParamTagMap[key]
-			if !ok {
-				continue
-			}
 
-			delete(options, validatorSpec)
+		for i := range f.validators {
+			vp := &f.validators[i]
+			switch vp.kind {
+			case interfaceParamValidator:
+				validatefunc, ok := sv.interfaceParamTagMap[vp.key]
+				if !ok || !v.CanInterface() {
+					continue
+				}
+				consumed[i] = true
 
-			field := fmt.Sprint(v)
-			if result := validatefunc(v.Interface(), ps[1:]...); (!result && !negate) || (result && negate) {
-				if customMsgExists {
-					return false, Error{t.Name, TruncatingErrorf(validatorStruct.customErrorMessage, field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
+				if result := validatefunc(v.Interface(), vp.params...); (!result && !vp.negate) || (result && vp.negate) {
+					err := validationError(f.name, vp, sv.formatValue(ctx, f, v), f.label(), interfaceOf(v))
+					if !sv.allErrors {
+						return false, err
+					}
+					errs = append(errs, err)
 				}
-				if negate {
-					return false, Error{t.Name, fmt.Errorf("%s does validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
+			case conditionalRequiredValidator:
+				// only checked for empty values, see checkRequired
+				consumed[i] = true
+			case crossFieldValidator:
+				consumed[i] = true
+
+				if err := checkCrossField(v, o, f.name, vp); err != nil {
+					if !sv.allErrors {
+						return false, err
+					}
+					errs = append(errs, err)
 				}
-				return false, Error{t.Name, fmt.Errorf("%s does not validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
 			}
 		}
 	}
@@ -1607,146 +1985,187 @@ func typeCheck(v reflect.Value, t reflect.StructField, o reflect.Value, options
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
 		reflect.Float32, reflect.Float64,
 		reflect.String:
+		if f.dive != nil {
+			return false, Error{Name: f.name, Err: fmt.Errorf("Validator dive doesn't support kind %s", v.Kind()), Validator: "dive", Path: []string{},
+				Field: f.name, Code: CodeUnsupportedKind, Value: interfaceOf(v)}
+		}
 		// for each tag option checks the map of validator functions
-		for _, validatorSpec := range optionsOrder {
-			validatorStruct := options[validatorSpec]
-			var negate bool
-			validator := validatorSpec
-			customMsgExists := len(validatorStruct.customErrorMessage) > 0
-
-			// checks whether the tag looks like '!something' or 'something'
-			if validator[0] == '!' {
-				validator = validator[1:]
-				negate = true
-			}
+		for i := range f.validators {
+			vp := &f.validators[i]
+			var result bool
 
-			// checks for param validators
-			for key, value := range ParamTagRegexMap {
-				ps := value.FindStringSubmatch(validator)
-				if len(ps) == 0 {
+			switch vp.kind {
+			case paramValidator:
+				validatefunc, ok := sv.paramTagMap[vp.key]
+				if !ok {
 					continue
 				}
+				consumed[i] = true
 
-				validatefunc, ok := ParamTagMap[key]
+				if !isStringableKind(v.Kind()) {
+					// type not yet supported, fail
+					err := Error{Name: f.name, Err: fmt.Errorf("Validator %s doesn't support kind %s", vp.validator, v.Kind()), Validator: vp.name, Path: []string{},
+						Field: f.name, Code: CodeUnsupportedKind, Params: vp.errorParams(), Value: interfaceOf(v)}
+					if !sv.allErrors {
+						return false, err
+					}
+					errs = append(errs, err)
+					continue
+				}
+				result = validatefunc(fmt.Sprint(v), vp.params...)
+			case tagValidator:
+				validatefunc, ok := sv.tagMap[vp.key]
 				if !ok {
 					continue
 				}
+				consumed[i] = true
 
-				delete(options, validatorSpec)
-
-				switch v.Kind() {
-				case reflect.String,
-					reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-					reflect.Float32, reflect.Float64:
-
-					field := fmt.Sprint(v) // make value into string, then validate with regex
-					if result := validatefunc(field, ps[1:]...); (!result && !negate) || (result && negate) {
-						if customMsgExists {
-							return false, Error{t.Name, TruncatingErrorf(validatorStruct.customErrorMessage, field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-						}
-						if negate {
-							return false, Error{t.Name, fmt.Errorf("%s does validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-						}
-						return false, Error{t.Name, fmt.Errorf("%s does not validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
+				if !isStringableKind(v.Kind()) {
+					//Not Yet Supported Types (Fail here!)
+					err := Error{Name: f.name, Err: valueMessage{
+						message:  fmt.Sprintf("Validator %s doesn't support kind %s for value %s", vp.validator, v.Kind(), sv.formatValue(ctx, f, v)),
+						redacted: fmt.Sprintf("Validator %s doesn't support kind %s for value %s", vp.validator, v.Kind(), f.label())}, Validator: vp.name, Path: []string{},
+						Field: f.name, Code: CodeUnsupportedKind, Value: interfaceOf(v)}
+					if !sv.allErrors {
+						return false, err
 					}
-				default:
-					// type not yet supported, fail
-					return false, Error{t.Name, fmt.Errorf("Validator %s doesn't support kind %s", validator, v.Kind()), false, stripParams(validatorSpec), []string{}}
+					errs = append(errs, err)
+					continue
 				}
+				result = validatefunc(fmt.Sprint(v))
+			default:
+				continue
 			}
 
-			if validatefunc, ok := TagMap[validator]; ok {
-				delete(options, validatorSpec)
-
-				switch v.Kind() {
-				case reflect.String,
-					reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-					reflect.Float32, reflect.Float64:
-					field := fmt.Sprint(v) // make value into string, then validate with regex
-					if result := validatefunc(field); !result && !negate || result && negate {
-						if customMsgExists {
-							return false, Error{t.Name, TruncatingErrorf(validatorStruct.customErrorMessage, field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-						}
-						if negate {
-							return false, Error{t.Name, fmt.Errorf("%s does validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-						}
-						return false, Error{t.Name, fmt.Errorf("%s does not validate as %s", field, validator), customMsgExists, stripParams(validatorSpec), []string{}}
-					}
-				default:
-					//Not Yet Supported Types (Fail here!)
-					err := fmt.Errorf("Validator %s doesn't support kind %s for value %v", validator, v.Kind(), v)
-					return false, Error{t.Name, err, false, stripParams(validatorSpec), []string{}}
+			if !result && !vp.negate || result && vp.negate {
+				err := validationError(f.name, vp, sv.formatValue(ctx, f, v), f.label(), interfaceOf(v))
+				if !sv.allErrors {
+					return false, err
 				}
+				errs = append(errs, err)
 			}
 		}
+		if len(errs) > 0 {
+			return false, errs
+		}
 		return true, nil
 	case reflect.Map:
+		if f.dive != nil {
+			if err := checkCollection(v, f, consumed); err != nil {
+				if !sv.allErrors {
+					return false, err
+				}
+				errs = append(errs, err)
+			}
+			return mergeErrors(errs)(sv.checkElements(ctx, v, f, o))
+		}
 		if v.Type().Key().Kind() != reflect.String {
 			return false, &UnsupportedTypeError{v.Type()}
 		}
-		var sv stringValues
-		sv = v.MapKeys()
-		sort.Sort(sv)
+		var keys stringValues
+		keys = v.MapKeys()
+		sort.Sort(keys)
 		result := true
-		for i, k := range sv {
+		for i, k := range keys {
 			var resultItem bool
 			var err error
 			if v.MapIndex(k).Kind() != reflect.Struct {
-				resultItem, err = typeCheck(v.MapIndex(k), t, o, options)
-				if err != nil {
-					return false, err
-				}
+				ef := f.element(keys[i].String(), f.tagPlan)
+				resultItem, err = sv.typeCheck(ctx, v.MapIndex(k), ef, o, consumed)
+				err = elementErrors(err, ef)
 			} else {
-				resultItem, err = ValidateStruct(v.MapIndex(k).Interface())
+				resultItem, err = sv.validateStructValue(ctx, v.MapIndex(k))
 				if err != nil {
-					err = prependPathToErrors(err, t.Name+"."+sv[i].Interface().(string))
+					err = prependPathToErrors(err, f.path(keys[i].String())...)
+				}
+			}
+			if err != nil {
+				if !sv.allErrors {
 					return false, err
 				}
+				errs = append(errs, err)
 			}
 			result = result && resultItem
 		}
-		return result, nil
+		return mergeErrors(errs)(result, nil)
 	case reflect.Slice, reflect.Array:
+		if f.dive != nil {
+			if err := checkCollection(v, f, consumed); err != nil {
+				if !sv.allErrors {
+					return false, err
+				}
+				errs = append(errs, err)
+			}
+			return mergeErrors(errs)(sv.checkElements(ctx, v, f, o))
+		}
 		result := true
 		for i := 0; i < v.Len(); i++ {
 			var resultItem bool
 			var err error
 			if v.Index(i).Kind() != reflect.Struct {
-				resultItem, err = typeCheck(v.Index(i), t, o, options)
-				if err != nil {
-					return false, err
-				}
+				ef := f.element(strconv.Itoa(i), f.tagPlan)
+				resultItem, err = sv.typeCheck(ctx, v.Index(i), ef, o, consumed)
+				err = elementErrors(err, ef)
 			} else {
-				resultItem, err = ValidateStruct(v.Index(i).Interface())
+				resultItem, err = sv.validateStructValue(ctx, v.Index(i))
 				if err != nil {
-					err = prependPathToErrors(err, t.Name+"."+strconv.Itoa(i))
+					err = prependPathToErrors(err, f.path(strconv.Itoa(i))...)
+				}
+			}
+			if err != nil {
+				if !sv.allErrors {
 					return false, err
 				}
+				errs = append(errs, err)
 			}
 			result = result && resultItem
 		}
-		return result, nil
+		return mergeErrors(errs)(result, nil)
 	case reflect.Interface:
 		// If the value is an interface then encode its element
 		if v.IsNil() {
-			return true, nil
+			return mergeErrors(errs)(true, nil)
 		}
-		return ValidateStruct(v.Interface())
+		return mergeErrors(errs)(sv.validateStructValue(ctx, v))
 	case reflect.Ptr:
 		// If the value is a pointer then checks its element
 		if v.IsNil() {
-			return true, nil
+			return mergeErrors(errs)(true, nil)
 		}
-		return typeCheck(v.Elem(), t, o, options)
+		return mergeErrors(errs)(sv.typeCheck(ctx, v.Elem(), f, o, consumed))
 	case reflect.Struct:
-		return true, nil
+		return mergeErrors(errs)(true, nil)
 	default:
 		return false, &UnsupportedTypeError{v.Type()}
 	}
 }
 
+// mergeErrors returns a function combining the failures collected in errs with the
+// result of a further check, so that all of them are reported together.
+func mergeErrors(errs Errors) func(bool, error) (bool, error) {
+	return func(result bool, err error) (bool, error) {
+		if len(errs) == 0 {
+			return result, err
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return false, errs
+	}
+}
+
+// isStringableKind reports whether values of kind k are validated through their fmt.Sprint form.
+func isStringableKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
 func stripParams(validatorString string) string {
 	return paramsRegexp.ReplaceAllString(validatorString, "")
 }
@@ -1770,6 +2189,10 @@ func isEmptyValue(v reflect.Value) bool {
 		return v.IsNil()
 	}
 
+	if !v.CanInterface() {
+		// read from an unexported field
+		return v.IsZero()
+	}
 	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
 }
 
@@ -1807,11 +2230,39 @@ func ErrorsByField(e error) map[string]string {
 	return m
 }
 
+// ErrorsByPath returns map of errors of the struct validated by ValidateStruct keyed by
+// the full dotted path of the fields, e.g. "addresses.0.street", using JSON names when present.
+// The messages of several errors for the same field are joined with ";".
+func ErrorsByPath(e error) map[string]string {
+	m := make(map[string]string)
+	if e == nil {
+		return m
+	}
+	for _, item := range flattenErrors(e) {
+		err, ok := item.(Error)
+		if !ok {
+			continue
+		}
+		path := err.fullName()
+		if message, ok := m[path]; ok {
+			m[path] = message + ";" + err.Err.Error()
+			continue
+		}
+		m[path] = err.Err.Error()
+	}
+	return m
+}
+
 // Error returns string equivalent for reflect.Type
 func (e *UnsupportedTypeError) Error() string {
 	return "validator: unsupported type: " + e.Type.String()
 }
 
+// Is reports whether target is ErrUnsupportedType.
+func (e *UnsupportedTypeError) Is(target error) bool {
+	return target == ErrUnsupportedType
+}
+
 func (sv stringValues) Len() int           { return len(sv) }
 func (sv stringValues) Swap(i, j int)      { sv[i], sv[j] = sv[j], sv[i] }
 func (sv stringValues) Less(i, j int) bool { return sv.get(i) < sv.get(j) }
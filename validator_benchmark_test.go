@@ -106,6 +106,22 @@ type benchValidateStruct struct {
 	AuthorIP string `valid:"ipv4"`
 }
 
+type benchValidateAddress struct {
+	Street  string `valid:"required,stringlength(1|64)"`
+	ZipCode string `valid:"numeric,length(5|5)"`
+	Country string `valid:"required,in(US|DE|FR)"`
+}
+
+type benchValidateRequest struct {
+	Name     string               `valid:"required,stringlength(2|32)" json:"name"`
+	Email    string               `valid:"required,email" json:"email"`
+	Age      int                  `valid:"range(18|130)" json:"age"`
+	Website  string               `valid:"url,maxstringlength(128)" json:"website"`
+	Tags     []string             `valid:"alphanum,runelength(1|16)" json:"tags"`
+	Address  benchValidateAddress `valid:"required" json:"address"`
+	Password string               `valid:"required,minstringlength(8),!lowercase" json:"-"`
+}
+
 var (
 	benchValidateStructOK = benchValidateStruct{Title: "MyPost", AuthorIP: "192.168.1.1"}
 	benchMapInput         = map[string]interface{}{
@@ -120,6 +136,15 @@ var (
 		"email":   "required,email",
 		"address": map[string]interface{}{"line1": "required,alphanum", "line2": "alphanum", "postal-code": "numeric"},
 	}
+	benchValidateRequestOK = benchValidateRequest{
+		Name:     "Bob",
+		Email:    "bob@example.com",
+		Age:      42,
+		Website:  "https://example.com",
+		Tags:     []string{"go", "validation", "api"},
+		Address:  benchValidateAddress{Street: "Main St 1", ZipCode: "12345", Country: "US"},
+		Password: "Sup3rSecret",
+	}
 	benchArray = []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 	benchCond  = func(value interface{}, index int) bool { return value.(int)%2 == 0 }
 )
@@ -684,6 +709,30 @@ func BenchmarkValidateStruct(b *testing.B) {
 	}
 }
 
+func BenchmarkValidateStructRequest(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateStruct(benchValidateRequestOK)
+	}
+}
+
+// BenchmarkValidateStructRequestUncached drops the compiled plans before every call,
+// which is what every ValidateStruct call did before plans were cached.
+func BenchmarkValidateStructRequestUncached(b *testing.B) {
+	v := New()
+	for i := 0; i < b.N; i++ {
+		v.cache.Store(&planCache{registry: v.registryVersion()})
+		_, _ = v.ValidateStruct(benchValidateRequestOK)
+	}
+}
+
+func BenchmarkValidateStructParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = ValidateStruct(benchValidateRequestOK)
+		}
+	})
+}
+
 func BenchmarkValidateStructAsync(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		okc, errc := ValidateStructAsync(benchValidateStructOK)
@@ -692,6 +741,35 @@ func BenchmarkValidateStructAsync(b *testing.B) {
 	}
 }
 
+type benchAttachment struct {
+	Name string `valid:"alpha"`
+	Body []byte `valid:"-"`
+}
+
+type benchUnexported struct {
+	tags       []string          `valid:"dive,alpha"`
+	labels     map[string]string `valid:"dive,keys,alpha,endkeys,numeric"`
+	attachment benchAttachment
+}
+
+// BenchmarkValidateStructUnexported validates unexported fields, which reflection can't pass to
+// Interface: their elements are copied as they are validated, and the nested fields without
+// validators, like attachment.Body, aren't copied.
+func BenchmarkValidateStructUnexported(b *testing.B) {
+	v := New(WithUnexportedFields(true))
+	doc := benchUnexported{tags: make([]string, 100), labels: make(map[string]string, 100),
+		attachment: benchAttachment{Name: benchAlpha, Body: make([]byte, 1<<16)}}
+	for i := range doc.tags {
+		key := string(rune('a'+i%26)) + string(rune('a'+i/26))
+		doc.tags[i] = benchAlpha
+		doc.labels[key] = benchNumeric
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = v.ValidateStruct(doc)
+	}
+}
+
 func BenchmarkValidateMapAsync(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		okc, errc := ValidateMapAsync(benchMapInput, benchMapSchema)
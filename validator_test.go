@@ -1,7 +1,9 @@
 package govalidator
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -2462,6 +2464,80 @@ func TestIsIn(t *testing.T) {
 	}
 }
 
+func TestItemsValidators(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		fn       InterfaceParamValidator
+		name     string
+		value    interface{}
+		params   []string
+		expected bool
+	}{
+		{MinItems, "MinItems", []string{"a", "b"}, []string{"2"}, true},
+		{MinItems, "MinItems", []string{"a"}, []string{"2"}, false},
+		{MinItems, "MinItems", map[string]int{"a": 1, "b": 2}, []string{"2"}, true},
+		{MinItems, "MinItems", "ab", []string{"1"}, false},
+		{MinItems, "MinItems", []string{"a"}, nil, false},
+		{MaxItems, "MaxItems", [2]int{1, 2}, []string{"2"}, true},
+		{MaxItems, "MaxItems", &[]int{1, 2, 3}, []string{"2"}, false},
+		{ItemsLength, "ItemsLength", []int{1, 2, 3}, []string{"1", "3"}, true},
+		{ItemsLength, "ItemsLength", []int{}, []string{"1", "3"}, false},
+		{ItemsLength, "ItemsLength", []int{1, 2, 3, 4}, []string{"1", "3"}, false},
+		{IsUnique, "IsUnique", []string{"a", "b"}, nil, true},
+		{IsUnique, "IsUnique", []string{"a", "b", "a"}, nil, false},
+		{IsUnique, "IsUnique", map[string]int{"a": 1, "b": 1}, nil, false},
+		{IsUnique, "IsUnique", [][]int{{1}, {2}}, nil, true},
+		{IsUnique, "IsUnique", [][]int{{1}, {1}}, nil, false},
+		{IsUnique, "IsUnique", []interface{}{1, []int{1}}, nil, true},
+		{IsUnique, "IsUnique", 1, nil, false},
+	}
+	for _, test := range tests {
+		actual := test.fn(test.value, test.params...)
+		if actual != test.expected {
+			t.Errorf("Expected %s(%v, %v) to be %v, got %v", test.name, test.value, test.params, test.expected, actual)
+		}
+	}
+}
+
+func TestItemsStruct(t *testing.T) {
+	t.Parallel()
+
+	type Post struct {
+		Tags    []string          `valid:"maxitems(3),unique,alpha"`
+		Authors []string          `valid:"required,minitems(1),dive,email"`
+		Meta    map[string]string `valid:"items(1|2)"`
+	}
+
+	var tests = []struct {
+		param    Post
+		expected string
+	}{
+		{Post{Tags: []string{"a", "b"}, Authors: []string{"foo@bar.com"}, Meta: map[string]string{"a": "1"}}, ""},
+		{Post{Authors: []string{"foo@bar.com"}, Meta: map[string]string{"a": "1"}}, ""},
+		{Post{Tags: []string{"a", "b", "c", "d"}, Authors: []string{"foo@bar.com"}, Meta: map[string]string{"a": "1"}}, "Tags: [a b c d] does not validate as maxitems(3)"},
+		{Post{Tags: []string{"a", "a"}, Authors: []string{"foo@bar.com"}, Meta: map[string]string{"a": "1"}}, "Tags: [a a] does not validate as unique"},
+		{Post{Tags: []string{"a", "1"}, Authors: []string{"foo@bar.com"}, Meta: map[string]string{"a": "1"}}, "Tags.1: 1 does not validate as alpha"},
+		{Post{Tags: []string{"a"}, Meta: map[string]string{"a": "1"}}, "Authors: non zero value required"},
+		{Post{Authors: []string{"foo@bar.com"}, Meta: map[string]string{"a": "1", "b": "2", "c": "3"}}, "Meta: map[a:1 b:2 c:3] does not validate as items(1|2)"},
+		// the number of items is checked for empty and nil collections too
+		{Post{Authors: []string{"foo@bar.com"}}, "Meta: map[] does not validate as items(1|2)"},
+		{Post{Authors: []string{"foo@bar.com"}, Meta: map[string]string{}}, "Meta: map[] does not validate as items(1|2)"},
+	}
+	for _, test := range tests {
+		ok, err := ValidateStruct(test.param)
+		if test.expected == "" {
+			if !ok || err != nil {
+				t.Errorf("Expected ValidateStruct(%v) to be valid, got %v", test.param, err)
+			}
+			continue
+		}
+		if ok || err == nil || err.Error() != test.expected {
+			t.Errorf("Expected ValidateStruct(%v) to fail with %q, got %v", test.param, test.expected, err)
+		}
+	}
+}
+
 type Address struct {
 	Street string `valid:"-"`
 	Zip    string `json:"zip" valid:"numeric,required"`
@@ -2664,6 +2740,31 @@ func TestFieldsRequiredByDefaultButExemptOrOptionalStruct(t *testing.T) {
 	SetFieldsRequiredByDefault(false)
 }
 
+func TestValidateStructSliceElements(t *testing.T) {
+	type Emails struct {
+		List []string `valid:"email"`
+	}
+
+	var tests = []struct {
+		param    Emails
+		expected bool
+	}{
+		{Emails{List: []string{"foo@bar.com", "bar@foo.com"}}, true},
+		{Emails{List: []string{"invalid", "foo@bar.com"}}, false},
+		{Emails{List: []string{"foo@bar.com", "invalid"}}, false},
+		{Emails{List: []string{"", "invalid"}}, false},
+	}
+	for _, test := range tests {
+		actual, err := ValidateStruct(test.param)
+		if actual != test.expected {
+			t.Errorf("Expected ValidateStruct(%q) to be %v, got %v", test.param, test.expected, actual)
+			if err != nil {
+				t.Errorf("Got Error on ValidateStruct(%q): %s", test.param, err)
+			}
+		}
+	}
+}
+
 func TestInvalidValidator(t *testing.T) {
 	type InvalidStruct struct {
 		Field int `valid:"someInvalidValidator"`
@@ -2709,6 +2810,70 @@ func TestCustomValidator(t *testing.T) {
 	}
 }
 
+type tenantKey struct{}
+
+func TestValidateStructCtx(t *testing.T) {
+	t.Parallel()
+
+	v := New()
+	v.AddCustomTypeValidatorCtx("tenantAllowed", func(ctx context.Context, i interface{}, o interface{}) bool {
+		allowed, _ := ctx.Value(tenantKey{}).(map[string]bool)
+		return allowed[i.(string)]
+	})
+
+	type Order struct {
+		Product string `valid:"tenantAllowed"`
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, map[string]bool{"apple": true})
+	if valid, err := v.ValidateStructCtx(ctx, Order{Product: "apple"}); !valid || err != nil {
+		t.Errorf("Got an unexpected result for allowed product: %t %s", valid, err)
+	}
+	if valid, err := v.ValidateStructCtx(ctx, Order{Product: "pear"}); valid || err == nil {
+		t.Errorf("Got an unexpected result for disallowed product: %t %s", valid, err)
+	}
+	// without the context value nothing is allowed
+	if valid, err := v.ValidateStruct(Order{Product: "apple"}); valid || err == nil {
+		t.Errorf("Got an unexpected result for product validated without context: %t %s", valid, err)
+	}
+
+	schema := map[string]interface{}{"product": "tenantAllowed"}
+	if valid, err := v.ValidateMapCtx(ctx, map[string]interface{}{"product": "apple"}, schema); !valid || err != nil {
+		t.Errorf("Got an unexpected result for allowed product in map: %t %s", valid, err)
+	}
+	if valid, err := v.ValidateMapCtx(ctx, map[string]interface{}{"product": "pear"}, schema); valid || err == nil {
+		t.Errorf("Got an unexpected result for disallowed product in map: %t %s", valid, err)
+	}
+}
+
+func TestValidateStructCtxCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	v := New()
+	v.AddCustomTypeValidatorCtx("cancelling", func(ctx context.Context, i interface{}, o interface{}) bool {
+		calls++
+		cancel()
+		return true
+	})
+
+	type Order struct {
+		First  string `valid:"cancelling"`
+		Second string `valid:"cancelling"`
+	}
+
+	if valid, err := v.ValidateStructCtx(ctx, Order{First: "a", Second: "b"}); valid || err != context.Canceled {
+		t.Errorf("Expected validation to stop with context.Canceled, got %t %v", valid, err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected validation to stop after the first field, got %d calls", calls)
+	}
+	if valid, err := v.ValidateMapCtx(ctx, map[string]interface{}{"a": "b"}, map[string]interface{}{"a": "cancelling"}); valid || err != context.Canceled {
+		t.Errorf("Expected map validation to return context.Canceled, got %t %v", valid, err)
+	}
+}
+
 type CustomByteArray [6]byte
 
 type StructWithCustomByteArray struct {
@@ -3349,7 +3514,7 @@ func TestErrorsByField(t *testing.T) {
 		{"CustomField", "An error occurred"},
 	}
 
-	err = Error{"CustomField", fmt.Errorf("An error occurred"), false, "hello", []string{}}
+	err = Error{Name: "CustomField", Err: fmt.Errorf("An error occurred"), Validator: "hello", Path: []string{}}
 	errs = ErrorsByField(err)
 
 	if len(errs) != 1 {
@@ -3392,6 +3557,66 @@ func TestErrorsByField(t *testing.T) {
 	}
 }
 
+func TestErrorsByPath(t *testing.T) {
+	t.Parallel()
+
+	type Item struct {
+		ID string `valid:"uuid" json:"id"`
+	}
+	type Order struct {
+		ID       string            `valid:"uuid" json:"id"`
+		Items    []Item            `json:"items"`
+		Tags     []string          `valid:"alpha"`
+		Meta     map[string]string `valid:"numeric" json:"meta"`
+		Customer struct {
+			ID string `valid:"uuid"`
+		} `json:"customer"`
+	}
+
+	order := Order{ID: "1", Items: []Item{{ID: "2"}, {ID: "3"}}, Tags: []string{"a", "b1"}, Meta: map[string]string{"x": "1", "y": "z"}}
+	order.Customer.ID = "4"
+	_, err := ValidateStruct(order)
+
+	expected := map[string]string{
+		"id":          "1 does not validate as uuid",
+		"items.0.id":  "2 does not validate as uuid",
+		"Tags.1":      "b1 does not validate as alpha",
+		"meta.y":      "z does not validate as numeric",
+		"customer.ID": "4 does not validate as uuid",
+	}
+	if actual := ErrorsByPath(err); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected ErrorsByPath to be %v, got %v", expected, actual)
+	}
+
+	var paths []string
+	for _, e := range flattenErrors(err) {
+		paths = append(paths, e.(Error).fullName())
+	}
+	if expected := []string{"id", "items.0.id", "Tags.1", "meta.y", "customer.ID"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected errors in declaration order %v, got %v", expected, paths)
+	}
+	if actual := flattenErrors(err)[1].(Error).Path; !reflect.DeepEqual(actual, []string{"items", "0"}) {
+		t.Errorf("Expected the path of a slice element to be [items 0], got %v", actual)
+	}
+}
+
+func TestValidateMapErrorOrder(t *testing.T) {
+	t.Parallel()
+
+	s := map[string]interface{}{"c": "1", "a": "2", "b": "3", "d": ""}
+	m := map[string]interface{}{"a": "alpha", "b": "alpha", "c": "alpha", "d": "alpha", "e": "required", "f": "required"}
+	for i := 0; i < 10; i++ {
+		_, err := ValidateMap(s, m)
+		var names []string
+		for _, e := range flattenErrors(err) {
+			names = append(names, e.(Error).Name)
+		}
+		if expected := []string{"a", "b", "c", "e", "f"}; !reflect.DeepEqual(names, expected) {
+			t.Fatalf("Expected errors in key order %v, got %v", expected, names)
+		}
+	}
+}
+
 func TestValidateStructPointers(t *testing.T) {
 	// Struct which uses pointers for values
 	type UserWithPointers struct {